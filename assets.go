@@ -0,0 +1,34 @@
+package emojify
+
+import (
+	"errors"
+	"io/fs"
+)
+
+// ErrNoAssets is returned when a local copy of the Twemoji assets is required but not available.
+var ErrNoAssets = errors.New("emojify: no assets available: use WithAssets or build with -tags emojify_embed")
+
+// embeddedAssets is a copy of Twemoji's assets directory, set when built with the emojify_embed tag.
+var embeddedAssets fs.FS
+
+// WithAssets specifies a local copy of the images, laid out according to [Provider.Path].
+// It is used by formats that embed the images themselves, such as [InlineSVG].
+// For example, os.DirFS("twemoji/assets") for Twemoji, which contains svg/ and 72x72/.
+//
+// By default, Twemoji assets are embedded in the binary when built with the emojify_embed build tag.
+func WithAssets(fsys fs.FS) Option {
+	return func(t *Twemoji) {
+		t.assets = fsys
+	}
+}
+
+func (tw Twemoji) readAsset(name string) ([]byte, error) {
+	fsys := tw.assets
+	if fsys == nil {
+		fsys = embeddedAssets
+	}
+	if fsys == nil {
+		return nil, ErrNoAssets
+	}
+	return fs.ReadFile(fsys, name)
+}
@@ -0,0 +1,25 @@
+//go:build emojify_embed
+
+package emojify
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Copied from Twemoji by script/gen.sh. Until then, only placeholders are embedded
+// and the build acts like one without the emojify_embed tag.
+//
+//go:embed all:assets/svg all:assets/72x72
+var assetsFS embed.FS
+
+func init() {
+	sub, err := fs.Sub(assetsFS, "assets")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := fs.Stat(sub, "svg/1f600.svg"); err != nil {
+		return
+	}
+	embeddedAssets = sub
+}
@@ -0,0 +1,37 @@
+//go:build emojify_embed
+
+package emojify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedAssets(t *testing.T) {
+	if embeddedAssets == nil {
+		t.Skip("no assets to embed: run script/gen.sh")
+	}
+
+	if got := New(WithFormat(InlineSVG)).Replace("😀"); !strings.HasPrefix(got, "<svg") {
+		t.Errorf("InlineSVG: Replace = %.100s", got)
+	}
+	if got := New(WithDataURI()).Replace("😀"); !strings.Contains(got, `src="data:image/svg+xml,`) {
+		t.Errorf("WithDataURI: Replace = %.100s", got)
+	}
+	if got := New(WithDataURI(), WithFormat(PNG)).Replace("😀"); !strings.Contains(got, `src="data:image/png;base64,`) {
+		t.Errorf("WithDataURI: Replace = %.100s", got)
+	}
+
+	handler := New().AssetHandler()
+	for _, path := range []string{"/svg/1f600.svg", "/72x72/1f600.png"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("AssetHandler: GET %s = %d", path, w.Code)
+		}
+	}
+}
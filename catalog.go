@@ -0,0 +1,224 @@
+package emojify
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Emoji describes an emoji known to this package, as given by Unicode's emoji-test.txt
+// and CLDR annotations.
+type Emoji struct {
+	// Text is the emoji's Unicode sequence.
+	Text string
+	// Name is the CLDR short name, such as "grinning face".
+	Name string
+	// Group is the emoji category, such as "Smileys & Emotion".
+	Group string
+	// Subgroup is the emoji subcategory, such as "face-smiling".
+	Subgroup string
+	// Keywords are CLDR search keywords.
+	Keywords []string
+	// Status is the qualification status of Text.
+	Status Status
+	// Version is the Emoji version that introduced this emoji.
+	Version EmojiVersion
+}
+
+// Status is the qualification status of an emoji sequence.
+// See: https://www.unicode.org/reports/tr51/#def_fully_qualified_emoji
+type Status uint8
+
+const (
+	// NonStandard emoji are Twemoji extensions not listed by Unicode, such as lone regional indicators.
+	NonStandard Status = iota
+	// Component emoji are building blocks of other emoji, such as skin tone modifiers.
+	Component
+	// FullyQualified emoji are the canonical form of an emoji.
+	FullyQualified
+	// MinimallyQualified emoji are missing some (non-initial) emoji presentation selectors.
+	MinimallyQualified
+	// Unqualified emoji are missing their emoji presentation selectors.
+	Unqualified
+)
+
+func (s Status) String() string {
+	switch s {
+	case NonStandard:
+		return "non-standard"
+	case Component:
+		return "component"
+	case FullyQualified:
+		return "fully-qualified"
+	case MinimallyQualified:
+		return "minimally-qualified"
+	case Unqualified:
+		return "unqualified"
+	}
+	return "Status(" + strconv.Itoa(int(s)) + ")"
+}
+
+// EmojiVersion is an Emoji version, such as E13.1.
+// Not to be confused with [Version], the Twemoji release.
+type EmojiVersion struct {
+	Major, Minor uint8
+}
+
+// ParseEmojiVersion parses versions such as "13.1" or "E13.1".
+func ParseEmojiVersion(s string) (EmojiVersion, error) {
+	major, minor, _ := strings.Cut(strings.TrimPrefix(s, "E"), ".")
+	if minor == "" {
+		minor = "0"
+	}
+	x, err := strconv.ParseUint(major, 10, 8)
+	if err != nil {
+		return EmojiVersion{}, fmt.Errorf("emojify: invalid emoji version %q: %w", s, err)
+	}
+	y, err := strconv.ParseUint(minor, 10, 8)
+	if err != nil {
+		return EmojiVersion{}, fmt.Errorf("emojify: invalid emoji version %q: %w", s, err)
+	}
+	return EmojiVersion{Major: uint8(x), Minor: uint8(y)}, nil
+}
+
+// Compare returns -1 if v is older than other, 1 if it is newer, or 0 if they are equal.
+func (v EmojiVersion) Compare(other EmojiVersion) int {
+	if n := cmp.Compare(v.Major, other.Major); n != 0 {
+		return n
+	}
+	return cmp.Compare(v.Minor, other.Minor)
+}
+
+func (v EmojiVersion) String() string {
+	return strconv.Itoa(int(v.Major)) + "." + strconv.Itoa(int(v.Minor))
+}
+
+func (r resource) emoji() Emoji {
+	text := r.str
+	if r.text != "" {
+		text = r.text
+	}
+	return Emoji{
+		Text:     text,
+		Name:     r.name,
+		Group:    r.group,
+		Subgroup: r.subgroup,
+		Keywords: r.keywords,
+		Status:   r.status,
+		Version:  r.version,
+	}
+}
+
+// catalog indexes twemojiData by text, and by text without emoji presentation selectors.
+var catalog = sync.OnceValue(func() map[string]int {
+	index := make(map[string]int, len(twemojiData)*2)
+	for i, item := range twemojiData {
+		index[item.str] = i
+	}
+	for i, item := range twemojiData {
+		stripped := strings.ReplaceAll(item.str, string(zwj), "")
+		if _, ok := index[stripped]; !ok {
+			index[stripped] = i
+		}
+	}
+	return index
+})
+
+// sequences returns the emoji of p with their metadata and image paths for format,
+// longest first so that sequences take precedence over their prefixes.
+// Results for the built-in providers are shared by every [Twemoji] and must not be modified.
+func sequences(p Provider, format Format) []resource {
+	switch p.(type) {
+	case twemojiProvider, notoProvider, openMojiProvider:
+	default:
+		return loadSequences(p, format)
+	}
+	key := sequenceKey{provider: p, format: format}
+	if cached, ok := sequenceCache.Load(key); ok {
+		return cached.([]resource)
+	}
+	cached, _ := sequenceCache.LoadOrStore(key, loadSequences(p, format))
+	return cached.([]resource)
+}
+
+// missingSequences returns the known emoji sequences without an image in seqs, left as text.
+// Otherwise, parts of them would be replaced, such as 🐦 and 🔥 of 🐦‍🔥.
+func missingSequences(seqs []resource) []resource {
+	have := make(map[string]bool, len(seqs))
+	for _, item := range seqs {
+		have[item.str] = true
+	}
+	var missing []resource
+	for _, item := range twemojiData {
+		if !have[item.str] && utf8.RuneCountInString(item.str) > 1 {
+			missing = append(missing, plainResource(item.str, item.str))
+		}
+	}
+	return missing
+}
+
+type sequenceKey struct {
+	provider Provider
+	format   Format
+}
+
+var sequenceCache sync.Map // sequenceKey → []resource
+
+func loadSequences(p Provider, format Format) []resource {
+	index := catalog()
+	emojis := p.Emojis()
+	seqs := make([]resource, 0, len(emojis))
+	for _, emoji := range emojis {
+		item := resource{str: emoji}
+		if i, ok := index[emoji]; ok {
+			item = twemojiData[i]
+			item.str = emoji
+		}
+		item.img = p.Path(emoji, format)
+		if item.img == "" {
+			continue
+		}
+		seqs = append(seqs, item)
+	}
+	slices.SortStableFunc(seqs, longestFirst)
+	return seqs
+}
+
+func longestFirst(a, b resource) int {
+	return cmp.Compare(len(b.str), len(a.str))
+}
+
+// Lookup returns information about the given emoji.
+// Emoji presentation selectors (U+FE0F) are optional.
+func (tw Twemoji) Lookup(emoji string) (Emoji, bool) {
+	return lookup(emoji)
+}
+
+func lookup(emoji string) (Emoji, bool) {
+	index := catalog()
+	i, ok := index[emoji]
+	if !ok {
+		i, ok = index[strings.ReplaceAll(emoji, string(zwj), "")]
+	}
+	if !ok {
+		return Emoji{}, false
+	}
+	return twemojiData[i].emoji(), true
+}
+
+// Emojis returns every emoji with an available image, in its fully-qualified form.
+func (tw Twemoji) Emojis() []Emoji {
+	index := catalog()
+	emojis := make([]Emoji, 0, len(twemojiData))
+	for _, item := range twemojiData {
+		if _, ok := index[item.qualified]; ok {
+			continue
+		}
+		emojis = append(emojis, item.emoji())
+	}
+	return emojis
+}
@@ -0,0 +1,114 @@
+package emojify
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	table := []struct {
+		in   string
+		want Emoji
+	}{
+		{
+			in: "😀",
+			want: Emoji{
+				Text:     "😀",
+				Name:     "grinning face",
+				Group:    "Smileys & Emotion",
+				Subgroup: "face-smiling",
+				Status:   FullyQualified,
+				Version:  EmojiVersion{1, 0},
+			},
+		},
+		{
+			in: "🐦‍⬛",
+			want: Emoji{
+				Text:     "🐦‍⬛",
+				Name:     "black bird",
+				Group:    "Animals & Nature",
+				Subgroup: "animal-bird",
+				Status:   FullyQualified,
+				Version:  EmojiVersion{15, 0},
+			},
+		},
+		{
+			// twemoji's file lacks VS16
+			in: "❤️",
+			want: Emoji{
+				Text:     "❤️",
+				Name:     "red heart",
+				Group:    "Smileys & Emotion",
+				Subgroup: "heart",
+				Status:   FullyQualified,
+				Version:  EmojiVersion{0, 6},
+			},
+		},
+		{
+			in: "❤",
+			want: Emoji{
+				Text:     "❤",
+				Name:     "red heart",
+				Group:    "Smileys & Emotion",
+				Subgroup: "heart",
+				Status:   Unqualified,
+				Version:  EmojiVersion{0, 6},
+			},
+		},
+	}
+	for _, try := range table {
+		t.Run(try.in, func(t *testing.T) {
+			got, ok := Lookup(try.in)
+			if !ok {
+				t.Fatal("not found")
+			}
+			if got.Text != try.want.Text || got.Name != try.want.Name || got.Group != try.want.Group ||
+				got.Subgroup != try.want.Subgroup || got.Status != try.want.Status || got.Version != try.want.Version {
+				t.Errorf("Lookup(%q) →\n got: %+v\nwant: %+v", try.in, got, try.want)
+			}
+		})
+	}
+
+	if _, ok := Lookup("hello"); ok {
+		t.Error("found non-emoji")
+	}
+}
+
+func TestParseEmojiVersion(t *testing.T) {
+	table := []struct {
+		in   string
+		want EmojiVersion
+	}{
+		{"13.1", EmojiVersion{13, 1}},
+		{"E0.6", EmojiVersion{0, 6}},
+		{"15", EmojiVersion{15, 0}},
+	}
+	for _, try := range table {
+		got, err := ParseEmojiVersion(try.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != try.want {
+			t.Errorf("ParseEmojiVersion(%q) = %v, want %v", try.in, got, try.want)
+		}
+	}
+	if _, err := ParseEmojiVersion("x.y"); err == nil {
+		t.Error("expected error")
+	}
+	if (EmojiVersion{13, 1}).Compare(EmojiVersion{13, 0}) != 1 {
+		t.Error("bad compare")
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	if !slices.ContainsFunc(twemojiData, func(item resource) bool { return len(item.keywords) > 0 }) {
+		t.Fatal("twemojiData has no CLDR keywords: run script/gen.sh")
+	}
+	if e, _ := Lookup("👍"); !slices.Contains(e.Keywords, "thumb") {
+		t.Errorf("Keywords(👍) = %q", e.Keywords)
+	}
+	// only a keyword of 🎉
+	if found := New().Search("celebration"); len(found) == 0 || found[0].Text != "🎉" {
+		t.Errorf("Search(celebration) = %v", found)
+	}
+}
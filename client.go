@@ -0,0 +1,146 @@
+package emojify
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// platformVersion is the oldest Emoji version that a platform can't display,
+// starting from the given OS version.
+type platformVersion struct {
+	os  [3]int
+	min EmojiVersion
+}
+
+// Emoji versions supported by each platform's system font, newest first.
+// Approximate: platforms roll out emoji in point releases, and some browsers bring their own fonts.
+var (
+	iosVersions = []platformVersion{
+		{os: [3]int{18, 4}, min: EmojiVersion{17, 0}},
+		{os: [3]int{17, 4}, min: EmojiVersion{16, 0}},
+		{os: [3]int{16, 4}, min: EmojiVersion{15, 1}},
+		{os: [3]int{15, 4}, min: EmojiVersion{15, 0}},
+		{os: [3]int{14, 5}, min: EmojiVersion{14, 0}},
+		{os: [3]int{14, 2}, min: EmojiVersion{13, 1}},
+		{os: [3]int{13, 2}, min: EmojiVersion{13, 0}},
+		{os: [3]int{12, 1}, min: EmojiVersion{12, 0}},
+		{os: [3]int{11, 1}, min: EmojiVersion{11, 0}},
+		{os: [3]int{10, 2}, min: EmojiVersion{5, 0}},
+	}
+	macVersions = []platformVersion{
+		{os: [3]int{15, 4}, min: EmojiVersion{17, 0}},
+		{os: [3]int{14, 4}, min: EmojiVersion{16, 0}},
+		{os: [3]int{13, 3}, min: EmojiVersion{15, 1}},
+		{os: [3]int{12, 3}, min: EmojiVersion{15, 0}},
+		{os: [3]int{11, 3}, min: EmojiVersion{14, 0}},
+		{os: [3]int{11, 1}, min: EmojiVersion{13, 1}},
+		{os: [3]int{10, 15, 1}, min: EmojiVersion{13, 0}},
+		{os: [3]int{10, 14, 1}, min: EmojiVersion{12, 0}},
+		{os: [3]int{10, 13, 1}, min: EmojiVersion{11, 0}},
+		{os: [3]int{10, 12, 2}, min: EmojiVersion{5, 0}},
+	}
+	androidVersions = []platformVersion{
+		{os: [3]int{16}, min: EmojiVersion{17, 0}},
+		{os: [3]int{15}, min: EmojiVersion{16, 0}},
+		{os: [3]int{14}, min: EmojiVersion{15, 1}},
+		{os: [3]int{13}, min: EmojiVersion{15, 0}},
+		{os: [3]int{12}, min: EmojiVersion{14, 0}},
+		{os: [3]int{11}, min: EmojiVersion{13, 1}},
+		{os: [3]int{10}, min: EmojiVersion{12, 1}},
+		{os: [3]int{9}, min: EmojiVersion{12, 0}},
+		{os: [3]int{8}, min: EmojiVersion{11, 0}},
+		{os: [3]int{7}, min: EmojiVersion{5, 0}},
+	}
+	// Windows 11 reports itself as NT 10.0, except in client hints.
+	windowsVersions = []platformVersion{
+		{os: [3]int{11}, min: EmojiVersion{14, 0}},
+		{os: [3]int{10}, min: EmojiVersion{12, 1}},
+		{os: [3]int{6, 2}, min: EmojiVersion{1, 0}},
+	}
+)
+
+var (
+	iosUA     = regexp.MustCompile(`(?:iPhone|CPU) OS (\d+)_(\d+)(?:_(\d+))?`)
+	macUA     = regexp.MustCompile(`Mac OS X (\d+)[_.](\d+)(?:[_.](\d+))?`)
+	androidUA = regexp.MustCompile(`Android (\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+	windowsUA = regexp.MustCompile(`Windows NT (\d+)\.(\d+)`)
+)
+
+// MinVersionForUserAgent returns the oldest Emoji version that the client with the given User-Agent
+// likely can't display natively, to be given to [WithMinVersion].
+// It returns the zero EmojiVersion, replacing every emoji, for unknown clients.
+//
+// User-Agents are frozen at old OS versions by some browsers, such as macOS 10.15.7 and Android 10,
+// which errs on the side of replacing emoji. Use [MinVersionForRequest] to take client hints into account.
+func MinVersionForUserAgent(ua string) EmojiVersion {
+	switch {
+	case strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPad") || strings.Contains(ua, "iPod"):
+		return minVersion(iosVersions, iosUA.FindStringSubmatch(ua))
+	case strings.Contains(ua, "Android"):
+		return minVersion(androidVersions, androidUA.FindStringSubmatch(ua))
+	case strings.Contains(ua, "Windows NT"):
+		m := windowsUA.FindStringSubmatch(ua)
+		if m != nil && m[1] == "10" {
+			// can't tell Windows 10 and 11 apart
+			m = []string{m[0], "10"}
+		}
+		return minVersion(windowsVersions, m)
+	case strings.Contains(ua, "Macintosh"):
+		return minVersion(macVersions, macUA.FindStringSubmatch(ua))
+	}
+	return EmojiVersion{}
+}
+
+// MinVersionForRequest returns the oldest Emoji version that the client making r
+// likely can't display natively, to be given to [WithMinVersion].
+// It prefers the Sec-CH-UA-Platform and Sec-CH-UA-Platform-Version client hints,
+// falling back to [MinVersionForUserAgent]. Ask for the platform version with the Accept-CH header.
+//
+// Creating a [Twemoji] is expensive, so keep one for each version rather than one for each request.
+func MinVersionForRequest(r *http.Request) EmojiVersion {
+	platform := strings.Trim(r.Header.Get("Sec-CH-UA-Platform"), `"`)
+	version := strings.Trim(r.Header.Get("Sec-CH-UA-Platform-Version"), `"`)
+	if platform != "" && version != "" {
+		m := append([]string{version}, strings.Split(version, ".")...)
+		switch platform {
+		case "Android":
+			return minVersion(androidVersions, m)
+		case "iOS":
+			return minVersion(iosVersions, m)
+		case "macOS":
+			return minVersion(macVersions, m)
+		case "Windows":
+			// platform versions 13 and up are Windows 11, 1 to 12 are Windows 10,
+			// and 0.x are older versions: 0.1 is Windows 7 (NT 6.1), 0.2 is 8, and 0.3 is 8.1
+			switch major, _ := strconv.Atoi(m[1]); {
+			case major >= 13:
+				return minVersion(windowsVersions, []string{version, "11"})
+			case major >= 1:
+				return minVersion(windowsVersions, []string{version, "10"})
+			case len(m) > 2:
+				return minVersion(windowsVersions, []string{version, "6", m[2]})
+			}
+		}
+	}
+	return MinVersionForUserAgent(r.Header.Get("User-Agent"))
+}
+
+// minVersion looks up the OS version in match, a regular expression match with the version's parts as submatches.
+func minVersion(table []platformVersion, match []string) EmojiVersion {
+	if len(match) < 2 {
+		return EmojiVersion{}
+	}
+	var os [3]int
+	for i := 0; i < len(os) && i+1 < len(match); i++ {
+		os[i], _ = strconv.Atoi(match[i+1])
+	}
+	for _, pv := range table {
+		if slices.Compare(os[:], pv.os[:]) >= 0 {
+			return pv.min
+		}
+	}
+	return EmojiVersion{}
+}
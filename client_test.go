@@ -0,0 +1,68 @@
+package emojify
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMinVersionForUserAgent(t *testing.T) {
+	tests := []struct {
+		ua   string
+		want EmojiVersion
+	}{
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Mobile/15E148 Safari/604.1", EmojiVersion{16, 0}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 14_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.0 Mobile/15E148 Safari/604.1", EmojiVersion{13, 1}},
+		{"Mozilla/5.0 (iPad; CPU OS 16_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.2 Mobile/15E148 Safari/604.1", EmojiVersion{15, 0}},
+		{"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36", EmojiVersion{15, 0}},
+		{"Mozilla/5.0 (Linux; Android 8.1.0; SM-J710F) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.99 Mobile Safari/537.36", EmojiVersion{11, 0}},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", EmojiVersion{12, 1}},
+		{"Mozilla/5.0 (Windows NT 6.1; Win64; x64; rv:109.0) Gecko/20100101 Firefox/115.0", EmojiVersion{}},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15", EmojiVersion{13, 0}},
+		{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", EmojiVersion{}},
+		{"", EmojiVersion{}},
+	}
+	for _, test := range tests {
+		if got := MinVersionForUserAgent(test.ua); got != test.want {
+			t.Errorf("MinVersionForUserAgent(%q) = %v, want %v", test.ua, got, test.want)
+		}
+	}
+}
+
+func TestMinVersionForRequest(t *testing.T) {
+	tests := []struct {
+		platform, version string
+		want              EmojiVersion
+	}{
+		{`"Windows"`, `"15.0.0"`, EmojiVersion{14, 0}},
+		{`"Windows"`, `"10.0.0"`, EmojiVersion{12, 1}},
+		{`"Windows"`, `"1.0.0"`, EmojiVersion{12, 1}},
+		{`"Windows"`, `"0.3.0"`, EmojiVersion{1, 0}}, // Windows 8.1
+		{`"Windows"`, `"0.1.0"`, EmojiVersion{}},     // Windows 7
+		{`"Android"`, `"14.0.0"`, EmojiVersion{15, 1}},
+		{`"macOS"`, `"14.5.0"`, EmojiVersion{16, 0}},
+		{`"Linux"`, `""`, EmojiVersion{13, 0}}, // falls back to the User-Agent
+	}
+	for _, test := range tests {
+		r, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+		r.Header.Set("Sec-CH-UA-Platform", test.platform)
+		r.Header.Set("Sec-CH-UA-Platform-Version", test.version)
+		if got := MinVersionForRequest(r); got != test.want {
+			t.Errorf("MinVersionForRequest(%s %s) = %v, want %v", test.platform, test.version, got, test.want)
+		}
+	}
+}
+
+func TestMinVersion(t *testing.T) {
+	tw := New(WithMinVersion(EmojiVersion{Major: 15}))
+	if got, want := foundEmoji(tw, "😀 🫠 🫨 🐦‍⬛ 🍋‍🟩"), "🫨 🐦‍⬛ 🍋‍🟩"; got != want {
+		t.Errorf("WithMinVersion(15.0): found %q, want %q", got, want)
+	}
+	if got := New(WithMinVersion(EmojiVersion{})).Replace("😀"); !strings.Contains(got, "<img") {
+		t.Errorf("WithMinVersion(0.0): Replace = %s", got)
+	}
+}
@@ -0,0 +1,107 @@
+package emojify
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"net/url"
+
+	"golang.org/x/net/html"
+)
+
+// CustomEmoji is a site-specific emoji, such as Slack's :partyparrot:.
+type CustomEmoji struct {
+	// Name of the emoji, such as "partyparrot", matched as :partyparrot:.
+	Name string
+	// Token is the text to match instead of :Name:, if set.
+	Token string
+	// URL of the image.
+	URL string
+	// Width and Height of the image, in pixels. Default is the size given by [WithSize].
+	Width, Height int
+	// Alt is the image's alt text. Default is the token.
+	Alt string
+}
+
+func (c CustomEmoji) token() string {
+	if c.Token != "" {
+		return c.Token
+	}
+	if c.Name == "" {
+		return ""
+	}
+	return ":" + c.Name + ":"
+}
+
+// WithCustomEmoji adds custom emoji, replaced alongside the built-in emoji.
+// Custom emoji take precedence over built-in emoji and shortcodes with the same text.
+// When custom emoji share a token, the earlier one wins.
+func WithCustomEmoji(emojis ...CustomEmoji) Option {
+	return func(t *Twemoji) {
+		t.custom = append(t.custom, emojis...)
+	}
+}
+
+// ReloadCustom returns a copy of tw with its custom emoji replaced by emojis.
+// The built-in emoji are reused as-is, so this is much cheaper than calling [New] again.
+// tw is unchanged, so it can continue to be used concurrently.
+func (tw Twemoji) ReloadCustom(emojis ...CustomEmoji) (Twemoji, error) {
+	if tw.replacer == nil {
+		return defaults().ReloadCustom(emojis...)
+	}
+	tw.custom = emojis
+	tw.searchIndex = new(searchOnce)
+	if err := tw.index(); err != nil {
+		return Twemoji{}, err
+	}
+	if tw.jumbo != nil {
+		tw.jumbo = new(jumboOnce)
+	}
+	return tw, nil
+}
+
+// Custom returns the custom emoji of tw.
+func (tw Twemoji) Custom() []CustomEmoji {
+	if tw.replacer == nil {
+		return defaults().Custom()
+	}
+	return append([]CustomEmoji(nil), tw.custom...)
+}
+
+func (tw Twemoji) customResource(c CustomEmoji) (resource, error) {
+	token := c.token()
+	if token == "" {
+		return resource{}, &OptionError{Option: "WithCustomEmoji", Value: c.URL, Err: errors.New("no name or token")}
+	}
+	if _, err := url.Parse(c.URL); err != nil {
+		return resource{}, &OptionError{Option: "WithCustomEmoji", Value: c.URL, Err: err}
+	}
+	if c.Width < 0 || c.Height < 0 {
+		return resource{}, &OptionError{Option: "WithCustomEmoji", Value: token, Err: errors.New("negative size")}
+	}
+	size := tw.size
+	if c.Width != 0 || c.Height != 0 {
+		size = Size{Width: c.Width, Height: c.Height}
+	}
+	e := Emoji{Text: token, Name: c.Name, Status: NonStandard}
+	alt := c.Alt
+	if alt == "" && tw.altName {
+		alt = tw.label(e)
+	}
+	node, err := tw.imgNode(e, c.URL, cmp.Or(alt, token), size)
+	if err != nil {
+		return resource{}, err
+	}
+	var buf bytes.Buffer
+	if err := html.Render(&buf, node); err != nil {
+		return resource{}, err
+	}
+	return resource{
+		str:    token,
+		img:    c.URL,
+		node:   node,
+		elem:   buf.String(),
+		name:   c.Name,
+		status: NonStandard,
+	}, nil
+}
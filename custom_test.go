@@ -0,0 +1,110 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestCustomEmoji(t *testing.T) {
+	tw := New(WithCustomEmoji(
+		CustomEmoji{Name: "partyparrot", URL: "/emoji/partyparrot.gif"},
+		CustomEmoji{Token: "(coffee)", URL: "/emoji/coffee.png", Width: 32, Height: 32, Alt: "coffee"},
+	))
+
+	parrot := `<img draggable="false" class="emoji" src="/emoji/partyparrot.gif" width="72" height="72" alt=":partyparrot:"/>`
+	coffee := `<img draggable="false" class="emoji" src="/emoji/coffee.png" width="32" height="32" alt="coffee"/>`
+	globe := `<img draggable="false" class="emoji" src="https://cdn.jsdelivr.net/gh/jdecked/twemoji@15.1.0/assets/svg/1f30e.svg" width="72" height="72" alt="🌎"/>`
+
+	want := "hi " + parrot + " " + coffee + globe
+	if got := tw.Replace("hi :partyparrot: (coffee)🌎"); got != want {
+		t.Errorf("Replace →\n got: %q\nwant: %q", got, want)
+	}
+
+	want = "&lt;hi&gt; " + parrot
+	if got := string(tw.HTML("<hi> :partyparrot:")); got != want {
+		t.Errorf("HTML →\n got: %q\nwant: %q", got, want)
+	}
+
+	var buf strings.Builder
+	w := tw.NewWriter(&buf)
+	for _, chunk := range []string{"a (cof", "fee) b"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "a "+coffee+" b"; got != want {
+		t.Errorf("NewWriter →\n got: %q\nwant: %q", got, want)
+	}
+
+	matches := tw.FindAll(":partyparrot:")
+	if len(matches) != 1 || matches[0].Emoji.Name != "partyparrot" || matches[0].Emoji.Status != NonStandard {
+		t.Errorf("FindAll: unexpected matches: %+v", matches)
+	}
+}
+
+func TestCustomEmojiPrecedence(t *testing.T) {
+	tw := New(
+		WithShortcodes(GitHub),
+		WithCustomEmoji(CustomEmoji{Name: "+1", URL: "/emoji/thumbsup.gif"}),
+	)
+	want := `<img draggable="false" class="emoji" src="/emoji/thumbsup.gif" width="72" height="72" alt=":+1:"/>`
+	if got := tw.Replace(":+1:"); got != want {
+		t.Errorf("override →\n got: %q\nwant: %q", got, want)
+	}
+
+	// a custom prefix of a longer built-in sequence doesn't shadow it
+	tw = New(WithCustomEmoji(CustomEmoji{Token: "❤", URL: "/emoji/heart.gif"}))
+	if got := tw.Replace("❤️‍🔥"); !strings.Contains(got, "2764-fe0f-200d-1f525.svg") {
+		t.Errorf("custom emoji shadowed a longer sequence: %q", got)
+	}
+}
+
+func TestReloadCustom(t *testing.T) {
+	tw := New(WithCustomEmoji(CustomEmoji{Name: "old", URL: "/emoji/old.png"}))
+	reloaded, err := tw.ReloadCustom(CustomEmoji{Name: "new", URL: "/emoji/new.png"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := reloaded.Replace(":old:"); got != ":old:" {
+		t.Errorf("reloaded still replaces removed emoji: %q", got)
+	}
+	if got := reloaded.Replace(":new:"); !strings.Contains(got, "/emoji/new.png") {
+		t.Errorf("reloaded didn't replace new emoji: %q", got)
+	}
+	if got := reloaded.Replace("🌎"); !strings.Contains(got, "1f30e.svg") {
+		t.Errorf("reloaded lost built-in emoji: %q", got)
+	}
+	// the original is unchanged
+	if got := tw.Replace(":old: :new:"); !strings.Contains(got, "/emoji/old.png") || !strings.Contains(got, ":new:") {
+		t.Errorf("original changed by reload: %q", got)
+	}
+	if custom := reloaded.Custom(); len(custom) != 1 || custom[0].Name != "new" {
+		t.Errorf("Custom() = %+v", custom)
+	}
+
+	if _, err := tw.ReloadCustom(CustomEmoji{URL: "/emoji/nameless.png"}); err == nil {
+		t.Error("expected error for custom emoji without a name")
+	}
+}
+
+func TestCustomEmojiInlineSVG(t *testing.T) {
+	tw := New(WithFormat(InlineSVG), WithAssets(testAssets()), WithCustomEmoji(CustomEmoji{Name: "parrot", URL: "/parrot.gif"}))
+	doc, err := html.Parse(strings.NewReader("<p>:parrot: :parrot:</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.ReplaceHTML(doc)
+	var buf strings.Builder
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), `<img draggable="false" class="emoji" src="/parrot.gif"`); n != 2 {
+		t.Errorf("expected 2 images, got %d: %s", n, buf.String())
+	}
+}
@@ -0,0 +1,63 @@
+package emojify
+
+import (
+	"encoding/base64"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// WithDataURI embeds images as data: URIs instead of linking to the CDN,
+// for self-contained HTML such as emails or exports.
+// Requires local assets, see [WithAssets].
+func WithDataURI() Option {
+	return func(t *Twemoji) {
+		t.dataURI = true
+	}
+}
+
+// encodeDataURI returns a data: URI of the given image.
+func (tw Twemoji) encodeDataURI(name string) (string, error) {
+	raw, err := tw.readAsset(name)
+	if err != nil {
+		return "", err
+	}
+	if path.Ext(name) == ".png" {
+		return "data:image/png;base64," + base64.StdEncoding.EncodeToString(raw), nil
+	}
+	return "data:image/svg+xml," + escapeSVG(minifySVG(string(raw))), nil
+}
+
+var (
+	svgProlog  = regexp.MustCompile(`<\?xml.*?\?>|<!--.*?-->`)
+	svgBetween = regexp.MustCompile(`>\s+<`)
+	svgSpace   = regexp.MustCompile(`\s+`)
+)
+
+func minifySVG(svg string) string {
+	svg = svgProlog.ReplaceAllString(svg, "")
+	svg = svgBetween.ReplaceAllString(svg, "><")
+	svg = svgSpace.ReplaceAllString(svg, " ")
+	return strings.TrimSpace(svg)
+}
+
+// escapeSVG percent-encodes svg for use in a data: URI.
+// Quotes and ampersands are encoded too, as they would otherwise be escaped as HTML entities.
+func escapeSVG(svg string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	b.Grow(len(svg))
+	for i := 0; i < len(svg); i++ {
+		c := svg[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			strings.IndexByte("-._~!$()*+,;=:@/", c) != -1:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDataURI(t *testing.T) {
+	assets := testAssets()
+	assets["svg/1f30e.svg"] = &fstest.MapFile{
+		Data: []byte("<?xml version=\"1.0\"?>\n<!-- globe -->\n<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 36 36\">\n\t<path fill=\"#88C9F9\" d=\"M18 0\"/>\n</svg>\n"),
+	}
+
+	svg := New(WithDataURI(), WithAssets(assets))
+	want := `hi <img draggable="false" class="emoji" src="data:image/svg+xml,%3Csvg%20xmlns=%22http://www.w3.org/2000/svg%22%20viewBox=%220%200%2036%2036%22%3E%3Cpath%20fill=%22%2388C9F9%22%20d=%22M18%200%22/%3E%3C/svg%3E" width="72" height="72" alt="🌎"/>`
+	if got := svg.Replace("hi 🌎"); got != want {
+		t.Errorf("svg →\n got: %q\nwant: %q", got, want)
+	}
+
+	png := New(WithDataURI(), WithFormat(PNG), WithAssets(assets))
+	// base64 of fake PNG "\x89PNG\r\n\x1a\n1f30e"
+	want = `hi <img draggable="false" class="emoji" src="data:image/png;base64,iVBORw0KGgoxZjMwZQ==" width="72" height="72" alt="🌎"/>`
+	if got := png.Replace("hi 🌎"); got != want {
+		t.Errorf("png →\n got: %q\nwant: %q", got, want)
+	}
+	if strings.Contains(png.Replace("🐦"), OfficialCDN) {
+		t.Error("data URI mode linked to the CDN")
+	}
+}
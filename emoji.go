@@ -6,11 +6,14 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/fs"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
+	"golang.org/x/text/language"
 )
 
 const (
@@ -22,107 +25,316 @@ const (
 // Twemoji is a configuration/cache of emoji replacements.
 // The zero value will use [Default].
 type Twemoji struct {
-	cdn   string
-	class string
-	fmt   Format
-	attrs AttrFunc
+	provider   Provider
+	cdn        string
+	customCDN  bool
+	class      string
+	fmt        Format
+	attrs      AttrFunc
+	emojiAttrs EmojiAttrFunc
+
+	assets  fs.FS
+	dataURI bool
+
+	dialects []Dialect
+
+	skipTags  map[string]bool
+	skipAttrs []html.Attribute
+
+	wrapTag   string
+	wrapAttrs []html.Attribute
+
+	ariaLabel bool
+	title     bool
+	altName   bool
+	locale    language.Tag
+
+	skinTone SkinTone
+
+	textPresentation  bool
+	emojiPresentation bool
+	stripSelector     bool
+	allow, deny       map[rune]bool
+	filters           []func(Emoji) bool
+
+	sizes     string
+	size      Size
+	jumboSize *Size
+	jumbo     *jumboOnce // for text of only emoji
+
+	custom  []CustomEmoji
+	builtin []resource // rendered built-in emoji and shortcodes
 
 	replacer *strings.Replacer
 	nodes    map[rune][]resource
+	heads    string // ASCII characters that can begin a match
+
+	searchIndex *searchOnce
 }
 
 type resource struct {
-	str  string     // unicode text
-	img  string     // filename
-	node *html.Node // <img> element
+	str   string     // unicode text
+	img   string     // path of image, relative to CDN
+	node  *html.Node // <img> element
+	elem  string     // rendered node
+	text  string     // emoji text, if str is an alias such as a shortcode
+	plain bool       // str is rendered as text rather than an emoji, see WithTextPresentation
+
+	// metadata from emoji-test.txt and CLDR
+	qualified string // fully-qualified form, if str isn't
+	name      string
+	group     string
+	subgroup  string
+	keywords  []string
+	status    Status
+	version   EmojiVersion
 }
 
 // New creates a new [Twemoji] with the given set of [Option].
+// It panics if the options are invalid or the emoji fail to load, see [NewTwemoji].
 func New(opts ...Option) Twemoji {
+	t, err := NewTwemoji(opts...)
+	if err != nil {
+		panic(fmt.Errorf("twemoji failed to load: %w", err))
+	}
+	return t
+}
+
+// NewTwemoji creates a new [Twemoji] with the given set of [Option],
+// returning an error if the options are invalid or the emoji fail to load.
+// Invalid options are reported as [*OptionError], and invalid attributes (such as from an [AttrFunc]) as [*AttrError].
+func NewTwemoji(opts ...Option) (Twemoji, error) {
 	t := Twemoji{
-		cdn:   OfficialCDN,
-		fmt:   SVG,
-		class: defaultClass,
-		nodes: make(map[rune][]resource),
+		provider: TwemojiProvider,
+		fmt:      SVG,
+		class:    defaultClass,
+
+		skipTags:  make(map[string]bool, len(defaultSkipTags)),
+		skipAttrs: []html.Attribute{defaultSkipAttr},
+
+		wrapTag: "span",
+		sizes:   "72px",
+		size:    defaultSize,
+	}
+	for _, tag := range defaultSkipTags {
+		t.skipTags[tag] = true
 	}
 	for _, opt := range opts {
 		opt(&t)
 	}
+	if err := t.validate(); err != nil {
+		return Twemoji{}, err
+	}
+	t.searchIndex = new(searchOnce)
+	if !t.customCDN {
+		t.cdn = t.provider.CDN()
+	}
 	if err := t.load(); err != nil {
-		panic(fmt.Errorf("twemoji failed to load: %w", err))
+		return Twemoji{}, err
 	}
-	return t
+	if t.jumboSize != nil {
+		t.jumbo = new(jumboOnce)
+	}
+	return t, nil
 }
 
 func (tw *Twemoji) load() error {
-	keyvals := make([]string, 0, len(twemojiData)*2)
+	tw.builtin = make([]resource, 0, len(twemojiData))
+	var loaded map[string]resource
+	if len(tw.dialects) > 0 {
+		loaded = make(map[string]resource, len(twemojiData))
+	}
+	seqs := sequences(tw.provider, tw.fmt.image())
+	toned := tw.tonedSequences(seqs)
 	var buf bytes.Buffer
-	for _, item := range twemojiData {
-		item.node = tw.node(item.str, item.img)
+	for _, item := range seqs {
+		if !tw.filter(item.emoji()) {
+			// left as-is, rather than replacing parts of it such as the letters of a flag
+			tw.builtin = append(tw.builtin, plainResource(item.str, item.str))
+			continue
+		}
+		// only the image is toned: the alt text stays as written, so it can be unreplaced
+		img := item.img
+		if v, ok := toned[item.str]; ok {
+			img = v.img
+		}
+		var err error
+		item.node, err = tw.node(item.emoji(), img)
+		if err != nil {
+			return err
+		}
 
 		buf.Reset()
 		if err := html.Render(&buf, item.node); err != nil {
 			return err
 		}
 
-		elem := buf.String()
-		keyvals = append(keyvals, item.str, elem)
+		item.elem = buf.String()
+		if tw.presents(item) {
+			tw.builtin = append(tw.builtin, item)
+		} else {
+			// left as-is, so the rest of the sequence isn't replaced either
+			tw.builtin = append(tw.builtin, plainResource(item.str, item.str))
+		}
+		if loaded != nil {
+			loaded[item.str] = item
+		}
+	}
+	tw.builtin = append(tw.builtin, missingSequences(seqs)...)
+	tw.builtin = append(tw.builtin, tw.textPresentations(tw.builtin)...)
+	// shortcodes share the <img> of their emoji
+	seen := make(map[string]bool)
+	for _, dialect := range tw.dialects {
+		for _, sc := range shortcodeData {
+			if sc.dialect != dialect || seen[sc.code] {
+				continue
+			}
+			seen[sc.code] = true
+			item, ok := loaded[sc.str]
+			if !ok {
+				continue
+			}
+			item.text = item.str
+			item.str = ":" + sc.code + ":"
+			tw.builtin = append(tw.builtin, item)
+		}
+	}
+	return tw.index()
+}
+
+// index prepares replacements for the built-in and custom emoji.
+func (tw *Twemoji) index() error {
+	items := make([]resource, 0, len(tw.custom)+len(tw.builtin))
+	for _, c := range tw.custom {
+		item, err := tw.customResource(c)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+	items = append(items, tw.builtin...)
+	// stable, so custom emoji take precedence over built-ins.
+	if !slices.IsSortedFunc(items, longestFirst) {
+		slices.SortStableFunc(items, longestFirst)
+	}
 
+	keyvals := make([]string, 0, len(items)*2)
+	tw.nodes = make(map[rune][]resource)
+	var heads []byte
+	for _, item := range items {
+		keyvals = append(keyvals, item.str, item.elem)
 		head, _ := utf8.DecodeRuneInString(item.str)
-		matches := tw.nodes[head]
-		matches = append(matches, item)
-		tw.nodes[head] = matches
+		tw.nodes[head] = append(tw.nodes[head], item)
+		if head < utf8.RuneSelf && !slices.Contains(heads, byte(head)) {
+			heads = append(heads, byte(head))
+		}
 	}
 	tw.replacer = strings.NewReplacer(keyvals...)
+	tw.heads = string(heads)
 	return nil
 }
 
-func (tw Twemoji) node(emoji string, src string) *html.Node {
-	dir := tw.fmt.dir()
-	if tw.fmt == PNG {
-		src = src[:len(src)-len("svg")] + "png"
+func (tw Twemoji) node(e Emoji, src string) (*html.Node, error) {
+	if tw.fmt == InlineSVG {
+		svg, err := tw.svgNode(e, src)
+		if err != nil {
+			return nil, err
+		}
+		if svg.Attr, err = tw.applyAttrs(e, svg.Attr); err != nil {
+			return nil, err
+		}
+		return svg, nil
+	}
+	href, err := tw.href(src)
+	if err != nil {
+		return nil, err
+	}
+	alt := e.Text
+	if tw.altName {
+		alt = tw.label(e)
+	}
+	switch tw.fmt {
+	case Picture:
+		img, err := tw.imgNode(e, href, alt, tw.size)
+		if err != nil {
+			return nil, err
+		}
+		return tw.picture(e.Text, img)
+	case Srcset:
+		srcset, err := tw.srcset(e.Text, href)
+		if err != nil {
+			return nil, err
+		}
+		return tw.imgNode(e, href, alt, tw.size,
+			html.Attribute{Key: "srcset", Val: srcset},
+			html.Attribute{Key: "sizes", Val: tw.sizes},
+		)
+	}
+	return tw.imgNode(e, href, alt, tw.size)
+}
+
+// href returns the URL of the image with the given path.
+func (tw Twemoji) href(src string) (string, error) {
+	if tw.dataURI {
+		return tw.encodeDataURI(src)
 	}
+	return tw.cdn + src, nil
+}
+
+// imgNode returns an <img> element for e, with extra attributes following src.
+func (tw Twemoji) imgNode(e Emoji, href, alt string, size Size, extra ...html.Attribute) (*html.Node, error) {
 	img := &html.Node{
 		Type:     html.ElementNode,
 		Data:     "img",
 		DataAtom: atom.Img,
 		Attr: []html.Attribute{
 			{Key: "draggable", Val: "false"},
-			{Key: "class", Val: tw.class},
-			{Key: "src", Val: tw.cdn + dir + src},
-			{Key: "width", Val: "72"},
-			{Key: "height", Val: "72"},
-			{Key: "alt", Val: emoji},
 		},
 	}
-	if tw.attrs != nil {
-		img.Attr = tw.attrs(emoji, img.Attr)
+	img.Attr = append(img.Attr, tw.classAttrs()...)
+	img.Attr = append(img.Attr, html.Attribute{Key: "src", Val: href})
+	img.Attr = append(img.Attr, extra...)
+	img.Attr = append(img.Attr, size.attrs()...)
+	img.Attr = append(img.Attr, html.Attribute{Key: "alt", Val: alt})
+	img.Attr = append(img.Attr, tw.labelAttrs(e)...)
+	var err error
+	if img.Attr, err = tw.applyAttrs(e, img.Attr); err != nil {
+		return nil, err
 	}
-	return img
+	return img, nil
 }
 
 // Option used in [New].
 type Option func(*Twemoji)
 
 // WithCDN specifies the CDN (i.e. URL root) for the emoji image assets.
-// Default value is the official (jsDelivr) CDN.
+// Default value is the provider's CDN, for Twemoji the official (jsDelivr) CDN.
 func WithCDN(href string) Option {
 	if href != "" && !strings.HasSuffix(href, "/") {
 		href = href + "/"
 	}
 	return func(t *Twemoji) {
 		t.cdn = href
+		t.customCDN = true
 	}
 }
 
 // WithClass specifies the class given to emoji replacement <img> elements.
-// Default is "emoji".
+// Default is "emoji". An empty class leaves out the class attribute.
 func WithClass(class string) Option {
 	return func(t *Twemoji) {
 		t.class = class
 	}
 }
 
+// classAttrs returns the class attribute of emoji images, if any.
+func (tw Twemoji) classAttrs() []html.Attribute {
+	if strings.TrimSpace(tw.class) == "" {
+		return nil
+	}
+	return []html.Attribute{{Key: "class", Val: tw.class}}
+}
+
 // AttrFunc is a function for choosing attributes for Twemoji <img> tags.
 type AttrFunc func(emoji string, defaults []html.Attribute) []html.Attribute
 
@@ -133,6 +345,17 @@ func WithAttrs(fn AttrFunc) Option {
 	}
 }
 
+// EmojiAttrFunc is like [AttrFunc], but is given the emoji's metadata, such as its name.
+type EmojiAttrFunc func(emoji Emoji, defaults []html.Attribute) []html.Attribute
+
+// WithEmojiAttrs specifies a custom HTML attribute function given the emoji's metadata.
+// It's called after the function given by [WithAttrs], if any.
+func WithEmojiAttrs(fn EmojiAttrFunc) Option {
+	return func(t *Twemoji) {
+		t.emojiAttrs = fn
+	}
+}
+
 // WithFormat specifies the desired image format (default SVG).
 func WithFormat(f Format) Option {
 	return func(t *Twemoji) {
@@ -140,6 +363,44 @@ func WithFormat(f Format) Option {
 	}
 }
 
+// WithShortcodes enables replacement of shortcodes such as :thumbsup: in the given dialects.
+// When dialects disagree on a shortcode, the earlier dialect wins.
+func WithShortcodes(dialects ...Dialect) Option {
+	return func(t *Twemoji) {
+		t.dialects = dialects
+	}
+}
+
+// WithSkipElements specifies the elements whose text is left alone by [Twemoji.ReplaceHTML],
+// replacing the default set of code, kbd, math, noscript, pre, samp, script, style, svg, template, textarea, and title.
+// Call with no arguments to replace text in every element.
+func WithSkipElements(tags ...string) Option {
+	return func(t *Twemoji) {
+		t.skipTags = make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			t.skipTags[strings.ToLower(tag)] = true
+		}
+	}
+}
+
+// WithSkipAttr specifies an additional attribute that opts an element and its descendants out of replacement,
+// such as translate="no". By default, data-emojify="off" is honored.
+func WithSkipAttr(key, val string) Option {
+	return func(t *Twemoji) {
+		t.skipAttrs = append(t.skipAttrs, html.Attribute{Key: key, Val: val})
+	}
+}
+
+// WithWrapper specifies the element that wraps text rewritten by [Twemoji.ReplaceHTML].
+// Default is a <span> with no attributes.
+// An empty tag disables the wrapper, splicing text and images directly into the parent element.
+func WithWrapper(tag string, attrs ...html.Attribute) Option {
+	return func(t *Twemoji) {
+		t.wrapTag = strings.ToLower(tag)
+		t.wrapAttrs = attrs
+	}
+}
+
 // Format of emoji replacement images.
 type Format string
 
@@ -148,23 +409,39 @@ const (
 	SVG Format = "svg"
 	// PNG (72x72 px) images.
 	PNG Format = "png"
+	// Picture outputs a <picture> element with an SVG <source> and a PNG <img> fallback,
+	// for clients that can't display SVG images.
+	Picture Format = "picture"
+	// Srcset outputs PNG <img> elements with a srcset of every resolution offered by the provider
+	// (see [SizedProvider]), and the sizes given by [WithSizes].
+	Srcset Format = "srcset"
+	// InlineSVG embeds <svg> elements instead of linking to images, no CDN required.
+	// Requires local assets, see [WithAssets].
+	//
+	// [Twemoji.ReplaceHTML] and [Twemoji.RewriteHTML] define each emoji's image once per call as a <symbol>,
+	// and repeated emoji <use> it. Other methods output self-contained <svg> elements.
+	InlineSVG Format = "inline-svg"
 )
 
-func (f Format) dir() string {
+// image returns the format of the underlying image file.
+func (f Format) image() Format {
 	switch f {
-	case SVG:
-		return "svg/"
-	case PNG:
-		return "72x72/"
+	case InlineSVG:
+		return SVG
+	case Picture, Srcset:
+		return PNG
 	}
-	return "/"
+	return f
 }
 
 // Replace returns a copy of s with all emojis replaced by <img> tags.
 // Does NOT sanitize s. Use ReplaceHTML instead to safely replace HTML text.
 func (tw Twemoji) Replace(s string) string {
 	if tw.replacer == nil {
-		return Default.Replace(s)
+		return defaults().Replace(s)
+	}
+	if tw.jumbo != nil && tw.OnlyEmoji(s) {
+		return tw.loadJumbo().Replace(s)
 	}
 	return tw.replacer.Replace(s)
 }
@@ -173,7 +450,10 @@ func (tw Twemoji) Replace(s string) string {
 // Does NOT sanitize s. Use ReplaceHTML instead to safely replace HTML text.
 func (tw Twemoji) WriteString(w io.Writer, s string) (n int, err error) {
 	if tw.replacer == nil {
-		return Default.WriteString(w, s)
+		return defaults().WriteString(w, s)
+	}
+	if tw.jumbo != nil && tw.OnlyEmoji(s) {
+		return tw.loadJumbo().WriteString(w, s)
 	}
 	return tw.replacer.WriteString(w, s)
 }
@@ -2,6 +2,7 @@ package emojify
 
 import (
 	"fmt"
+	"html/template"
 	"io"
 	"strings"
 	"testing"
@@ -115,3 +116,184 @@ func BenchmarkTwemojiHTML(b *testing.B) {
 		ReplaceHTML(doc)
 	}
 }
+
+func TestShortcodes(t *testing.T) {
+	thumbsup := `<img draggable="false" class="emoji" src="` + OfficialCDN + `svg/1f44d.svg" width="72" height="72" alt="👍"/>`
+	table := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "nice :thumbsup: :+1: :thumbs_up:",
+			want: "nice " + thumbsup + " " + thumbsup + " :thumbs_up:",
+		},
+		{
+			in:   "::thumbsup:: :unknown: :thumbsup",
+			want: ":" + thumbsup + ": :unknown: :thumbsup",
+		},
+	}
+	tw := New(WithShortcodes(GitHub))
+	for _, try := range table {
+		t.Run(try.in, func(t *testing.T) {
+			if got := tw.Replace(try.in); got != try.want {
+				t.Errorf("Replace(%q) →\n got: %q\nwant: %q", try.in, got, try.want)
+			}
+
+			text := &html.Node{Type: html.TextNode, Data: try.in}
+			doc := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P, FirstChild: text, LastChild: text}
+			text.Parent = doc
+			tw.ReplaceHTML(doc)
+			var buf strings.Builder
+			if err := html.Render(&buf, doc.FirstChild); err != nil {
+				t.Fatal(err)
+			}
+			if want := "<span>" + try.want + "</span>"; buf.String() != want {
+				t.Errorf("ReplaceHTML(%q) →\n got: %q\nwant: %q", try.in, buf.String(), want)
+			}
+		})
+	}
+
+	if got := New().Replace(":thumbsup:"); got != ":thumbsup:" {
+		t.Error("shortcodes replaced without WithShortcodes:", got)
+	}
+	if got := New(WithShortcodes(CLDR)).HTML(":thumbs_up: & :thumbsup:"); got != template.HTML(thumbsup+" &amp; :thumbsup:") {
+		t.Error("unexpected CLDR output:", got)
+	}
+}
+
+func TestSkipElements(t *testing.T) {
+	const src = `<p>👍</p><pre><code>👍</code></pre><script>"👍"</script>` +
+		`<div data-emojify="off"><b>👍</b></div><div translate="no">👍</div>`
+	render := func(tw Twemoji) string {
+		body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+		frags, err := html.ParseFragment(strings.NewReader(src), body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, frag := range frags {
+			body.AppendChild(frag)
+		}
+		tw.ReplaceHTML(body)
+		var buf strings.Builder
+		for node := body.FirstChild; node != nil; node = node.NextSibling {
+			if err := html.Render(&buf, node); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return buf.String()
+	}
+
+	img := New().Replace("👍")
+	if got, want := render(New()), `<p><span>`+img+`</span></p><pre><code>👍</code></pre><script>"👍"</script>`+
+		`<div data-emojify="off"><b>👍</b></div><div translate="no"><span>`+img+`</span></div>`; got != want {
+		t.Errorf("default →\n got: %q\nwant: %q", got, want)
+	}
+	if got, want := render(New(WithSkipElements("p", "script"), WithSkipAttr("translate", "no"))), `<p>👍</p><pre><code><span>`+img+`</span></code></pre><script>"👍"</script>`+
+		`<div data-emojify="off"><b>👍</b></div><div translate="no">👍</div>`; got != want {
+		t.Errorf("custom →\n got: %q\nwant: %q", got, want)
+	}
+
+	// skipped ancestor
+	text := &html.Node{Type: html.TextNode, Data: "👍"}
+	code := &html.Node{Type: html.ElementNode, Data: "code", DataAtom: atom.Code}
+	b := &html.Node{Type: html.ElementNode, Data: "b", DataAtom: atom.B}
+	code.AppendChild(b)
+	b.AppendChild(text)
+	ReplaceHTML(b)
+	if b.FirstChild != text {
+		t.Error("replaced text inside of <code>")
+	}
+}
+
+func TestWithWrapper(t *testing.T) {
+	const in = "hello 🐦‍⬛ world 🌎 for 🐦 and 🦤! 5️⃣"
+	table := []struct {
+		name string
+		tw   Twemoji
+		want string
+	}{
+		{
+			name: "default",
+			tw:   New(),
+			want: `<p><b>hi</b><span>` + Replace(in) + `</span><i>bye</i></p>`,
+		},
+		{
+			name: "custom",
+			tw:   New(WithWrapper("ins", html.Attribute{Key: "class", Val: "emojified"})),
+			want: `<p><b>hi</b><ins class="emojified">` + Replace(in) + `</ins><i>bye</i></p>`,
+		},
+		{
+			name: "none",
+			tw:   New(WithWrapper("")),
+			want: `<p><b>hi</b>` + Replace(in) + `<i>bye</i></p>`,
+		},
+	}
+	for _, try := range table {
+		t.Run(try.name, func(t *testing.T) {
+			doc := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P}
+			doc.AppendChild(&html.Node{Type: html.ElementNode, Data: "b", DataAtom: atom.B})
+			doc.FirstChild.AppendChild(&html.Node{Type: html.TextNode, Data: "hi"})
+			doc.AppendChild(&html.Node{Type: html.TextNode, Data: in})
+			doc.AppendChild(&html.Node{Type: html.ElementNode, Data: "i", DataAtom: atom.I})
+			doc.LastChild.AppendChild(&html.Node{Type: html.TextNode, Data: "bye"})
+
+			try.tw.ReplaceHTML(doc)
+			checkLinks(t, doc)
+			var buf strings.Builder
+			if err := html.Render(&buf, doc); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != try.want {
+				t.Errorf("ReplaceHTML →\n got: %q\nwant: %q", got, try.want)
+			}
+
+			try.tw.UnreplaceHTML(doc)
+			checkLinks(t, doc)
+			buf.Reset()
+			if err := html.Render(&buf, doc); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := buf.String(), `<p><b>hi</b>`+in+`<i>bye</i></p>`; got != want {
+				t.Errorf("UnreplaceHTML →\n got: %q\nwant: %q", got, want)
+			}
+		})
+	}
+}
+
+// checkLinks verifies the consistency of parent and sibling links.
+func checkLinks(t *testing.T, parent *html.Node) {
+	t.Helper()
+	var prev *html.Node
+	for node := parent.FirstChild; node != nil; node = node.NextSibling {
+		if node.Parent != parent {
+			t.Errorf("bad parent of %q: %v", node.Data, node.Parent)
+		}
+		if node.PrevSibling != prev {
+			t.Errorf("bad previous sibling of %q: %v", node.Data, node.PrevSibling)
+		}
+		checkLinks(t, node)
+		prev = node
+	}
+	if parent.LastChild != prev {
+		t.Errorf("bad last child of %q: %v", parent.Data, parent.LastChild)
+	}
+}
+
+func TestDefaultLazy(t *testing.T) {
+	if Default.replacer != nil {
+		t.Error("Default loaded eagerly")
+	}
+	var zero Twemoji
+	if got, want := zero.Replace("🌎"), Replace("🌎"); got != want || !strings.Contains(got, "<img") {
+		t.Errorf("zero value →\n got: %q\nwant: %q", got, want)
+	}
+
+	// built-in providers share their sequence index
+	a, b := sequences(TwemojiProvider, SVG), sequences(TwemojiProvider, SVG)
+	if &a[0] != &b[0] {
+		t.Error("sequence index not shared")
+	}
+	if c := sequences(TwemojiProvider, PNG); &a[0] == &c[0] {
+		t.Error("sequence index shared between formats")
+	}
+}
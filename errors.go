@@ -0,0 +1,158 @@
+package emojify
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// OptionError is returned by [NewTwemoji] when an [Option] is given an invalid value.
+type OptionError struct {
+	// Option is the name of the option, such as "WithCDN".
+	Option string
+	// Value is the invalid value.
+	Value string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *OptionError) Error() string {
+	msg := "emojify: invalid " + e.Option + " value " + strconv.Quote(e.Value)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// AttrError is returned by [NewTwemoji] when an attribute key is not a valid HTML attribute name,
+// such as a key returned by an [AttrFunc].
+type AttrError struct {
+	// Emoji is the emoji given to the AttrFunc, if any.
+	Emoji string
+	// Key is the invalid attribute key.
+	Key string
+}
+
+func (e *AttrError) Error() string {
+	msg := "emojify: invalid attribute key " + strconv.Quote(e.Key)
+	if e.Emoji != "" {
+		msg += " for emoji " + strconv.Quote(e.Emoji)
+	}
+	return msg
+}
+
+// validate checks the options of tw.
+func (tw Twemoji) validate() error {
+	if tw.provider == nil {
+		return &OptionError{Option: "WithProvider", Value: "<nil>"}
+	}
+	if tw.customCDN {
+		if err := validateCDN(tw.cdn); err != nil {
+			return &OptionError{Option: "WithCDN", Value: tw.cdn, Err: err}
+		}
+	}
+	if !tw.size.valid() {
+		return &OptionError{Option: "WithSize", Value: fmt.Sprintf("%+v", tw.size)}
+	}
+	if tw.jumboSize != nil && !tw.jumboSize.valid() {
+		return &OptionError{Option: "WithJumboSize", Value: fmt.Sprintf("%+v", *tw.jumboSize)}
+	}
+	if slices.ContainsFunc(tw.filters, func(fn func(Emoji) bool) bool { return fn == nil }) {
+		return &OptionError{Option: "WithFilter", Value: "nil"}
+	}
+	if tw.skinTone != NoSkinTone && !isSkinTone(rune(tw.skinTone)) {
+		return &OptionError{Option: "WithDefaultSkinTone", Value: tw.skinTone.String()}
+	}
+	switch tw.fmt {
+	case SVG, PNG, Picture, Srcset, InlineSVG:
+	default:
+		return &OptionError{Option: "WithFormat", Value: string(tw.fmt)}
+	}
+	if tw.wrapTag != "" && !validTagName(tw.wrapTag) {
+		return &OptionError{Option: "WithWrapper", Value: tw.wrapTag}
+	}
+	for _, attr := range tw.wrapAttrs {
+		if !validAttrName(attr.Key) {
+			return &OptionError{Option: "WithWrapper", Value: tw.wrapTag, Err: &AttrError{Key: attr.Key}}
+		}
+	}
+	for _, attr := range tw.skipAttrs {
+		if !validAttrName(attr.Key) {
+			return &OptionError{Option: "WithSkipAttr", Value: attr.Key, Err: &AttrError{Key: attr.Key}}
+		}
+	}
+	return nil
+}
+
+// validateCDN checks that href can be used as the root of image URLs.
+func validateCDN(href string) error {
+	u, err := url.Parse(href)
+	if err != nil {
+		return err
+	}
+	switch {
+	case u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https":
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	case u.Scheme != "" && u.Host == "":
+		return errors.New("missing host")
+	case strings.ContainsAny(href, "?#"):
+		return errors.New("must not have a query or fragment")
+	}
+	return nil
+}
+
+// applyAttrs returns the attributes of e's element, validating those chosen by [AttrFunc] and [EmojiAttrFunc].
+func (tw Twemoji) applyAttrs(e Emoji, attrs []html.Attribute) ([]html.Attribute, error) {
+	if tw.attrs == nil && tw.emojiAttrs == nil {
+		return attrs, nil
+	}
+	if tw.attrs != nil {
+		attrs = tw.attrs(e.Text, attrs)
+	}
+	if tw.emojiAttrs != nil {
+		attrs = tw.emojiAttrs(e, attrs)
+	}
+	for _, attr := range attrs {
+		if !validAttrName(attr.Key) {
+			return nil, &AttrError{Emoji: e.Text, Key: attr.Key}
+		}
+	}
+	return attrs, nil
+}
+
+// validAttrName reports whether key is a valid HTML attribute name.
+// See: https://html.spec.whatwg.org/multipage/syntax.html#attributes-2
+func validAttrName(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if unicode.IsControl(r) || unicode.IsSpace(r) || strings.ContainsRune(`"'<>/=`, r) || r == unicode.ReplacementChar {
+			return false
+		}
+	}
+	return true
+}
+
+// validTagName reports whether tag is a valid HTML element name, including custom elements.
+func validTagName(tag string) bool {
+	for i, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-'):
+		default:
+			return false
+		}
+	}
+	return tag != ""
+}
@@ -0,0 +1,77 @@
+package emojify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestNewTwemoji(t *testing.T) {
+	if _, err := NewTwemoji(WithCDN("https://example.com/twemoji/"), WithClass("emoji big")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTwemoji(WithCDN("/static/twemoji")); err != nil {
+		t.Fatal(err)
+	}
+	// no class
+	tw, err := NewTwemoji(WithClass(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tw.Replace("😀"); strings.Contains(got, "class=") {
+		t.Errorf("WithClass(\"\"): Replace = %s", got)
+	}
+	if got, err := tw.Unreplace(tw.Replace("😀")); err != nil || got != "😀" {
+		t.Errorf("WithClass(\"\"): Unreplace = %q, %v", got, err)
+	}
+
+	invalid := []struct {
+		opt    Option
+		option string
+	}{
+		{WithCDN("ftp://example.com/"), "WithCDN"},
+		{WithCDN("https:///twemoji/"), "WithCDN"},
+		{WithCDN("https://example.com/?v=1"), "WithCDN"},
+		{WithCDN("https://exa mple.com/"), "WithCDN"},
+		{WithFormat("gif"), "WithFormat"},
+		{WithProvider(nil), "WithProvider"},
+		{WithWrapper("my span"), "WithWrapper"},
+		{WithWrapper("span", html.Attribute{Key: `a"b`}), "WithWrapper"},
+		{WithSkipAttr("", "x"), "WithSkipAttr"},
+		{WithCustomEmoji(CustomEmoji{URL: "/x.png"}), "WithCustomEmoji"},
+	}
+	for _, test := range invalid {
+		_, err := NewTwemoji(test.opt)
+		var optErr *OptionError
+		if !errors.As(err, &optErr) {
+			t.Errorf("%s: expected *OptionError, got %v", test.option, err)
+			continue
+		}
+		if optErr.Option != test.option {
+			t.Errorf("%s: unexpected option: %v", test.option, err)
+		}
+	}
+}
+
+func TestNewTwemojiAttrError(t *testing.T) {
+	bad := WithAttrs(func(emoji string, defaults []html.Attribute) []html.Attribute {
+		return append(defaults, html.Attribute{Key: `onload="alert(1)" x`, Val: ""})
+	})
+	_, err := NewTwemoji(bad)
+	var attrErr *AttrError
+	if !errors.As(err, &attrErr) {
+		t.Fatalf("expected *AttrError, got %v", err)
+	}
+	if attrErr.Emoji == "" || attrErr.Key != `onload="alert(1)" x` {
+		t.Errorf("unexpected error: %+v", attrErr)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("New didn't panic")
+		}
+	}()
+	New(bad)
+}
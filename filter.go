@@ -0,0 +1,90 @@
+package emojify
+
+import (
+	"slices"
+	"strings"
+)
+
+// WithFilter only replaces emoji for which fn returns true.
+// It applies to the built-in emoji and their shortcodes; custom emoji are always replaced.
+// Multiple filters must all return true. Skin tone variants are checked as themselves, not as their base emoji.
+func WithFilter(fn func(Emoji) bool) Option {
+	return func(t *Twemoji) {
+		t.filters = append(t.filters, fn)
+	}
+}
+
+// WithGroups only replaces emoji in the given groups or subgroups, such as "Smileys & Emotion" or "country-flag".
+// See [Emoji.Group] and [Emoji.Subgroup].
+func WithGroups(groups ...string) Option {
+	return WithFilter(func(e Emoji) bool {
+		return inGroups(e, groups)
+	})
+}
+
+// WithoutGroups doesn't replace emoji in the given groups or subgroups, such as "Flags".
+// See [Emoji.Group] and [Emoji.Subgroup].
+func WithoutGroups(groups ...string) Option {
+	return WithFilter(func(e Emoji) bool {
+		return !inGroups(e, groups)
+	})
+}
+
+func inGroups(e Emoji, groups []string) bool {
+	return slices.ContainsFunc(groups, func(group string) bool {
+		return strings.EqualFold(group, e.Group) || strings.EqualFold(group, e.Subgroup)
+	})
+}
+
+// WithMaxVersion only replaces emoji introduced in Emoji version v or earlier.
+// Non-standard emoji are always replaced.
+func WithMaxVersion(v EmojiVersion) Option {
+	return WithFilter(func(e Emoji) bool {
+		return e.Status == NonStandard || e.Version.Compare(v) <= 0
+	})
+}
+
+// WithMinVersion only replaces emoji introduced in Emoji version v or later,
+// leaving older emoji for the client to display natively. See [MinVersionForRequest].
+// Non-standard emoji are always replaced.
+func WithMinVersion(v EmojiVersion) Option {
+	return WithFilter(func(e Emoji) bool {
+		return e.Status == NonStandard || e.Version.Compare(v) >= 0
+	})
+}
+
+// WithEmojis only replaces the given emoji, such as "👍" and "™".
+// Emoji presentation selectors and skin tones are ignored, so "👍" includes 👍🏽.
+func WithEmojis(emojis ...string) Option {
+	set := emojiSet(emojis)
+	return WithFilter(func(e Emoji) bool {
+		return set[toneKey(e.Text)]
+	})
+}
+
+// WithoutEmojis doesn't replace the given emoji, such as "™".
+// Emoji presentation selectors and skin tones are ignored, so "™" includes ™️.
+func WithoutEmojis(emojis ...string) Option {
+	set := emojiSet(emojis)
+	return WithFilter(func(e Emoji) bool {
+		return !set[toneKey(e.Text)]
+	})
+}
+
+func emojiSet(emojis []string) map[string]bool {
+	set := make(map[string]bool, len(emojis))
+	for _, emoji := range emojis {
+		set[toneKey(emoji)] = true
+	}
+	return set
+}
+
+// filter reports whether e passes the filters given by [WithFilter].
+func (tw Twemoji) filter(e Emoji) bool {
+	for _, fn := range tw.filters {
+		if !fn(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package emojify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	const in = "🇺🇸 🇯🇵 ™ ™️ 👍 👍🏽 😀 🫨 :+1: :us:"
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "WithoutGroups",
+			opts: []Option{WithoutGroups("country-flag"), WithoutEmojis("™")},
+			want: "👍 👍🏽 😀 🫨 👍",
+		},
+		{
+			name: "WithGroups",
+			opts: []Option{WithGroups("flags")},
+			want: "🇺🇸 🇯🇵 🇺🇸",
+		},
+		{
+			name: "WithEmojis",
+			opts: []Option{WithEmojis("👍", "😀")},
+			want: "👍 👍🏽 😀 👍",
+		},
+		{
+			name: "WithMaxVersion",
+			opts: []Option{WithMaxVersion(EmojiVersion{Major: 14})},
+			want: "🇺🇸 🇯🇵 ™ ™️ 👍 👍🏽 😀 👍 🇺🇸",
+		},
+		{
+			name: "WithFilter",
+			opts: []Option{WithFilter(func(e Emoji) bool { return e.Name != "thumbs up" })},
+			want: "🇺🇸 🇯🇵 ™ ™️ 👍🏽 😀 🫨 🇺🇸",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tw := New(append(test.opts, WithShortcodes(GitHub))...)
+			if got := foundEmoji(tw, in); got != test.want {
+				t.Errorf("found %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	// filtered emoji are left whole
+	tw := New(WithoutGroups("Flags"))
+	if got := tw.Replace("🇺🇸!"); got != "🇺🇸!" {
+		t.Errorf("Replace = %s", got)
+	}
+	if tw.OnlyEmoji("🇺🇸") {
+		t.Error("OnlyEmoji of filtered emoji")
+	}
+	// custom emoji are always replaced
+	tw = New(WithEmojis("👍"), WithCustomEmoji(CustomEmoji{Name: "parrot", URL: "/parrot.gif"}))
+	if got := tw.Replace(":parrot:"); !strings.Contains(got, "/parrot.gif") {
+		t.Errorf("Replace = %s", got)
+	}
+
+	_, err := NewTwemoji(WithFilter(nil))
+	var oe *OptionError
+	if !errors.As(err, &oe) || oe.Option != "WithFilter" {
+		t.Errorf("nil filter: got %v", err)
+	}
+}
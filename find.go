@@ -0,0 +1,78 @@
+package emojify
+
+import (
+	"iter"
+)
+
+// Match is an emoji found in text.
+type Match struct {
+	// Start and End are the byte offsets of the match, such that text[Start:End] is the emoji (or shortcode).
+	Start, End int
+	// Emoji is the matched emoji.
+	Emoji Emoji
+}
+
+// FindAll returns the location of every emoji in s.
+// Emoji are matched the same way as [Twemoji.Replace], including shortcodes if enabled.
+func (tw Twemoji) FindAll(s string) []Match {
+	var matches []Match
+	for m := range tw.matches(s) {
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// All returns an iterator over every emoji in s and its byte offset.
+func (tw Twemoji) All(s string) iter.Seq2[int, Emoji] {
+	return func(yield func(int, Emoji) bool) {
+		for m := range tw.matches(s) {
+			if !yield(m.Start, m.Emoji) {
+				return
+			}
+		}
+	}
+}
+
+// Segments returns an iterator that splits s into alternating runs of text and emoji.
+// Each segment is yielded along with its emoji, or nil if it is text.
+// Concatenating every segment results in s.
+func (tw Twemoji) Segments(s string) iter.Seq2[string, *Emoji] {
+	return func(yield func(string, *Emoji) bool) {
+		var pos int
+		for m := range tw.matches(s) {
+			if m.Start > pos && !yield(s[pos:m.Start], nil) {
+				return
+			}
+			if !yield(s[m.Start:m.End], &m.Emoji) {
+				return
+			}
+			pos = m.End
+		}
+		if pos < len(s) {
+			yield(s[pos:], nil)
+		}
+	}
+}
+
+func (tw Twemoji) matches(s string) iter.Seq[Match] {
+	if tw.replacer == nil {
+		return defaults().matches(s)
+	}
+	return func(yield func(Match) bool) {
+		var pos int
+		for pos < len(s) {
+			idx, m, _ := tw.next(s[pos:], true)
+			if m == nil {
+				return
+			}
+			start := pos + idx
+			pos = start + len(m.str)
+			if m.plain {
+				continue
+			}
+			if !yield(Match{Start: start, End: pos, Emoji: m.emoji()}) {
+				return
+			}
+		}
+	}
+}
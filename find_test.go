@@ -0,0 +1,75 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	const in = "hi 🐦‍⬛ & 5️⃣ :+1:"
+	got := New(WithShortcodes(GitHub)).FindAll(in)
+	want := []struct {
+		text  string
+		emoji string
+		name  string
+	}{
+		{"🐦‍⬛", "🐦‍⬛", "black bird"},
+		{"5️⃣", "5️⃣", "keycap: 5"},
+		{":+1:", "👍", "thumbs up"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected matches: %+v", got)
+	}
+	for i, m := range got {
+		if in[m.Start:m.End] != want[i].text || m.Emoji.Text != want[i].emoji || m.Emoji.Name != want[i].name {
+			t.Errorf("match %d: got %q %+v, want %+v", i, in[m.Start:m.End], m.Emoji, want[i])
+		}
+	}
+
+	if got := FindAll("nothing here"); got != nil {
+		t.Error("unexpected matches:", got)
+	}
+}
+
+func TestAll(t *testing.T) {
+	const in = "a🌎b🌎"
+	var offsets []int
+	for i, emoji := range All(in) {
+		if emoji.Text != "🌎" {
+			t.Error("unexpected emoji:", emoji.Text)
+		}
+		offsets = append(offsets, i)
+		break
+	}
+	if len(offsets) != 1 || offsets[0] != 1 {
+		t.Error("unexpected offsets:", offsets)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	const in = "hello 🐦‍⬛ world 🌎🐦!"
+	var parts []string
+	var joined strings.Builder
+	for text, emoji := range Segments(in) {
+		joined.WriteString(text)
+		if emoji != nil {
+			text = "[" + emoji.Name + "]"
+		}
+		parts = append(parts, text)
+	}
+	if got, want := strings.Join(parts, "|"), "hello |[black bird]| world |[globe showing Americas]|[bird]|!"; got != want {
+		t.Errorf("Segments →\n got: %q\nwant: %q", got, want)
+	}
+	if joined.String() != in {
+		t.Error("segments don't add up:", joined.String())
+	}
+}
+
+// foundEmoji returns the emoji found in text, separated by spaces.
+func foundEmoji(tw Twemoji, text string) string {
+	var found []string
+	for _, m := range tw.FindAll(text) {
+		found = append(found, m.Emoji.Text)
+	}
+	return strings.Join(found, " ")
+}
@@ -3,12 +3,32 @@ package emojify
 import (
 	"html/template"
 	"io"
+	"iter"
+	"net/http"
+	"sync"
 
 	"golang.org/x/net/html"
+	"golang.org/x/text/language"
 )
 
 // Default configuration using official CDN and SVG images.
-var Default = New()
+// It's loaded on first use, so that programs that don't need it don't pay for it.
+// Assign to it to change the configuration used by the package-level functions
+// and the zero value of [Twemoji].
+var Default Twemoji
+
+// standard is the configuration used by an unset [Default].
+var standard = sync.OnceValue(func() Twemoji {
+	return New()
+})
+
+// defaults returns Default, or the standard configuration if it hasn't been set.
+func defaults() Twemoji {
+	if Default.replacer != nil {
+		return Default
+	}
+	return standard()
+}
 
 // Replace returns a copy of s with emojis replaced by <img> tags.
 // Does NOT sanitize s.
@@ -24,7 +44,7 @@ func HTML(text string) template.HTML {
 
 // ReplaceHTML mutates the HTML of root, replacing emojis in text nodes with twemoji images.
 func ReplaceHTML(root *html.Node) {
-	replaceTextNodes(root, Default.replaceEmojis)
+	Default.ReplaceHTML(root)
 }
 
 // WriteString writes s to w with all emojis replaced by <img> tags.
@@ -32,3 +52,76 @@ func ReplaceHTML(root *html.Node) {
 func WriteString(w io.Writer, s string) (n int, err error) {
 	return Default.WriteString(w, s)
 }
+
+// SupportsSkinTone reports whether emoji has skin tone variants, see [Twemoji.SkinTones].
+func SupportsSkinTone(emoji string) bool {
+	return Default.SupportsSkinTone(emoji)
+}
+
+// StripSkinTone returns emoji without skin tones, such as 👍 for 👍🏽.
+func StripSkinTone(emoji string) string {
+	return Default.StripSkinTone(emoji)
+}
+
+// Normalize returns a copy of s with emojis converted to the given form, see [Twemoji.Normalize].
+func Normalize(s string, form Status) string {
+	return Default.Normalize(s, form)
+}
+
+// Lookup returns information about the given emoji.
+func Lookup(emoji string) (Emoji, bool) {
+	return Default.Lookup(emoji)
+}
+
+// Unreplace returns a copy of the HTML fragment s with emoji images turned back into text.
+func Unreplace(s string) (string, error) {
+	return Default.Unreplace(s)
+}
+
+// UnreplaceHTML mutates the HTML of root, turning emoji images back into text.
+func UnreplaceHTML(root *html.Node) {
+	Default.UnreplaceHTML(root)
+}
+
+// AssetHandler returns a handler serving Twemoji images from a local copy of the assets.
+// See [Twemoji.AssetHandler].
+func AssetHandler() http.Handler {
+	return Default.AssetHandler()
+}
+
+// RewriteHTML copies the HTML read from r to w, replacing emojis in text with twemoji images.
+// See [Twemoji.RewriteHTML].
+func RewriteHTML(w io.Writer, r io.Reader) error {
+	return Default.RewriteHTML(w, r)
+}
+
+// FindAll returns the location of every emoji in s.
+func FindAll(s string) []Match {
+	return Default.FindAll(s)
+}
+
+// All returns an iterator over every emoji in s and its byte offset.
+func All(s string) iter.Seq2[int, Emoji] {
+	return Default.All(s)
+}
+
+// Segments returns an iterator that splits s into alternating runs of text and emoji.
+func Segments(s string) iter.Seq2[string, *Emoji] {
+	return Default.Segments(s)
+}
+
+// OnlyEmoji reports whether s consists of at least one emoji and nothing else but whitespace.
+func OnlyEmoji(s string) bool {
+	return Default.OnlyEmoji(s)
+}
+
+// Name returns the CLDR short name of emoji in the language best matching tag, falling back to English.
+func Name(emoji string, tag language.Tag) string {
+	return Default.Name(emoji, tag)
+}
+
+// Search returns the emoji matching query by name, keyword, or shortcode, most relevant first.
+// See [Twemoji.SearchIndex] for details.
+func Search(query string, opts ...SearchOption) []Emoji {
+	return Default.Search(query, opts...)
+}
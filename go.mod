@@ -2,4 +2,7 @@ module github.com/guregu/emojify
 
 go 1.23.1
 
-require golang.org/x/net v0.30.0
+require (
+	golang.org/x/net v0.30.0
+	golang.org/x/text v0.19.0
+)
@@ -0,0 +1,160 @@
+package emojify
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AssetHandler returns a handler serving images from a local copy of the assets (see [WithAssets]),
+// under the paths given by the provider, for Twemoji svg/ and 72x72/. Combine it with [http.StripPrefix] and [WithCDN] to self-host images:
+//
+//	http.Handle("/static/twemoji/", http.StripPrefix("/static/twemoji/", emojify.AssetHandler()))
+//	tw := emojify.New(emojify.WithCDN("/static/twemoji/"))
+//
+// Images are served with strong ETags and long-lived immutable cache headers.
+// Pre-compressed variants (image.svg.br, image.svg.gz) are served when present and accepted by the client,
+// otherwise SVG images are gzipped on the fly. Unknown emoji are 404 Not Found.
+func (tw Twemoji) AssetHandler() http.Handler {
+	if tw.replacer == nil {
+		return defaults().AssetHandler()
+	}
+	known := make(map[string]bool)
+	sized, _ := tw.provider.(SizedProvider)
+	for _, emoji := range tw.provider.Emojis() {
+		for _, format := range []Format{SVG, PNG} {
+			if name := tw.provider.Path(emoji, format); name != "" {
+				known[name] = true
+			}
+		}
+		if sized == nil {
+			continue
+		}
+		for _, size := range sized.Sizes() {
+			if name := sized.SizedPath(emoji, size); name != "" {
+				known[name] = true
+			}
+		}
+	}
+	return &assetHandler{tw: tw, known: known}
+}
+
+type assetHandler struct {
+	tw    Twemoji
+	known map[string]bool // valid paths
+	cache sync.Map        // path → *asset
+}
+
+type asset struct {
+	etag string
+	mime string
+	// by content-encoding, "" is uncompressed
+	data map[string][]byte
+}
+
+var encodings = []string{"br", "gzip"}
+
+var extensions = map[string]string{
+	"br":   ".br",
+	"gzip": ".gz",
+}
+
+func (h *assetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	a, err := h.load(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", a.mime)
+	header.Set("Cache-Control", "public, max-age=31536000, immutable")
+	header.Set("Vary", "Accept-Encoding")
+
+	data, etag := a.data[""], a.etag
+	for _, enc := range encodings {
+		if variant, ok := a.data[enc]; ok && acceptsEncoding(r.Header.Get("Accept-Encoding"), enc) {
+			header.Set("Content-Encoding", enc)
+			data = variant
+			// strong ETags must differ by encoding
+			etag = strings.TrimSuffix(etag, `"`) + "-" + enc + `"`
+			break
+		}
+	}
+	header.Set("ETag", etag)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+}
+
+func (h *assetHandler) load(name string) (*asset, error) {
+	if cached, ok := h.cache.Load(name); ok {
+		return cached.(*asset), nil
+	}
+
+	if !h.known[name] {
+		return nil, fs.ErrNotExist
+	}
+	raw, err := h.tw.readAsset(name)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(raw)
+	a := &asset{
+		etag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+		mime: mime.TypeByExtension(path.Ext(name)),
+		data: map[string][]byte{"": raw},
+	}
+	for _, enc := range encodings {
+		if variant, err := h.tw.readAsset(name + extensions[enc]); err == nil {
+			a.data[enc] = variant
+		}
+	}
+	if path.Ext(name) == ".svg" {
+		a.mime = "image/svg+xml"
+		if _, ok := a.data["gzip"]; !ok {
+			var buf bytes.Buffer
+			zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+			if _, err := zw.Write(raw); err != nil {
+				return nil, err
+			}
+			if err := zw.Close(); err != nil {
+				return nil, err
+			}
+			a.data["gzip"] = buf.Bytes()
+		}
+	}
+	cached, _ := h.cache.LoadOrStore(name, a)
+	return cached.(*asset), nil
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header value allows enc.
+func acceptsEncoding(header, enc string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), enc) {
+			continue
+		}
+		q := strings.ReplaceAll(params, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
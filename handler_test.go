@@ -0,0 +1,88 @@
+package emojify
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestAssetHandler(t *testing.T) {
+	assets := testAssets()
+	assets["svg/1f30e.svg.br"] = &fstest.MapFile{Data: []byte("fake brotli")}
+	assets["svg/ffff.svg"] = &fstest.MapFile{Data: []byte("<svg></svg>")}
+	handler := New(WithAssets(assets)).AssetHandler()
+
+	get := func(path, encoding, etag string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if encoding != "" {
+			req.Header.Set("Accept-Encoding", encoding)
+		}
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	resp := get("/svg/1f426.svg", "", "")
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal("unexpected status:", resp.Status)
+	}
+	if string(body) != string(assets["svg/1f426.svg"].Data) {
+		t.Error("unexpected body:", string(body))
+	}
+	if got := resp.Header.Get("Content-Type"); got != "image/svg+xml" {
+		t.Error("bad content type:", got)
+	}
+	if got := resp.Header.Get("Cache-Control"); got != "public, max-age=31536000, immutable" {
+		t.Error("bad cache control:", got)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Error("missing etag")
+	}
+
+	if resp := get("/svg/1f426.svg", "", etag); resp.StatusCode != http.StatusNotModified {
+		t.Error("expected 304, got:", resp.Status)
+	}
+
+	resp = get("/svg/1f426.svg", "gzip, deflate", "")
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatal("expected gzip, got:", got)
+	}
+	if resp.Header.Get("ETag") == etag {
+		t.Error("gzip variant has the same etag")
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := io.ReadAll(zr); string(body) != string(assets["svg/1f426.svg"].Data) {
+		t.Error("unexpected gzipped body:", string(body))
+	}
+
+	resp = get("/svg/1f30e.svg", "gzip, br", "")
+	body, _ = io.ReadAll(resp.Body)
+	if got := resp.Header.Get("Content-Encoding"); got != "br" || string(body) != "fake brotli" {
+		t.Error("expected pre-compressed brotli, got:", got, string(body))
+	}
+	if resp := get("/svg/1f30e.svg", "gzip, br;q=0", ""); resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Error("expected gzip, got:", resp.Header.Get("Content-Encoding"))
+	}
+
+	resp = get("/72x72/1f426.png", "gzip", "")
+	if got := resp.Header.Get("Content-Type"); got != "image/png" || resp.Header.Get("Content-Encoding") != "" {
+		t.Error("bad png response:", got, resp.Header.Get("Content-Encoding"))
+	}
+
+	for _, path := range []string{"/svg/ffff.svg", "/svg/1f426.png", "/72x72/1f426.svg", "/1f426.svg", "/svg/../svg/1f426.svg"} {
+		if resp := get(path, "", ""); resp.StatusCode != http.StatusNotFound {
+			t.Error("expected 404 for", path, "got:", resp.Status)
+		}
+	}
+}
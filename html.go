@@ -2,9 +2,8 @@ package emojify
 
 import (
 	"html/template"
+	"slices"
 	"strings"
-	"unicode"
-	"unicode/utf8"
 
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
@@ -14,7 +13,7 @@ import (
 // Consider using with [html/template.Template.Funcs].
 func (tw Twemoji) HTML(text string) template.HTML {
 	if tw.replacer == nil {
-		return Default.HTML(text)
+		return defaults().HTML(text)
 	}
 	safe := html.EscapeString(text)
 	return template.HTML(tw.Replace(safe))
@@ -22,123 +21,173 @@ func (tw Twemoji) HTML(text string) template.HTML {
 
 // ReplaceHTML mutates the HTML of root, replacing emojis in text nodes with twemoji images.
 // Useful for replacing emoji in HTML you've already rendered (e.g. markdown rendering).
+// Text inside of code blocks and the like is left alone, see [WithSkipElements] and [WithSkipAttr].
 func (tw Twemoji) ReplaceHTML(root *html.Node) {
 	if tw.replacer == nil {
-		Default.ReplaceHTML(root)
+		defaults().ReplaceHTML(root)
 		return
 	}
-	replaceTextNodes(root, tw.replaceEmojis)
+	if tw.skipped(root) {
+		return
+	}
+	if tw.jumbo != nil && tw.onlyEmojiHTML(root) {
+		tw.loadJumbo().ReplaceHTML(root)
+		return
+	}
+	var seen map[string]bool
+	if tw.fmt == InlineSVG {
+		seen = make(map[string]bool)
+	}
+	tw.replaceTextNodes(root, seen)
 }
 
-const (
-	keycap = '\u20e3' // COMBINING ENCLOSING KEYCAP
-	zwj    = '\ufe0f' // VARIATION SELECTOR-16 (emoji selector)
-	lenZWJ = 3        // utf8.RuneLen(zwj)
+var (
+	defaultSkipTags = []string{
+		"code", "kbd", "math", "noscript", "pre", "samp",
+		"script", "style", "svg", "template", "textarea", "title",
+	}
+	defaultSkipAttr = html.Attribute{Key: "data-emojify", Val: "off"}
 )
 
-func (tw Twemoji) replaceEmojis(node *html.Node) *html.Node {
-	search := node.Data
-	// TODO: mutate node in-place? saves one alloc
-	span := &html.Node{
-		Type:     html.ElementNode,
-		Data:     "span",
-		DataAtom: atom.Span,
+// skips reports whether the text inside of the given element should be left alone.
+func (tw Twemoji) skips(tag string, attrs []html.Attribute) bool {
+	if tw.skipTags[tag] {
+		return true
 	}
-
-	var consumed int
-	emit := func(next *html.Node, idx int) {
-		consumed = idx
-		span.AppendChild(next)
+	for _, attr := range attrs {
+		for _, skip := range tw.skipAttrs {
+			if attr.Namespace == "" && attr.Key == skip.Key && strings.EqualFold(attr.Val, skip.Val) {
+				return true
+			}
+		}
 	}
+	return false
+}
 
-	var skip int
-	var hit bool
-scan:
-	for idx, char := range search {
-		if (char < unicode.MaxASCII) || (skip != 0 && skip > idx) {
-			continue
+// skipped reports whether node or any of its ancestors opt out of replacement.
+func (tw Twemoji) skipped(node *html.Node) bool {
+	for ; node != nil; node = node.Parent {
+		if node.Type == html.ElementNode && tw.skips(node.Data, node.Attr) {
+			return true
 		}
+	}
+	return false
+}
 
-		// special case for number keys: _ + ZWJ + $20E3
-		// they're the only emoji that can begin with ascii
-		if char == keycap {
-			// back up to start of sequence
-			// and skip zwj if present
-			if idx > lenZWJ {
-				if peek, _ := utf8.DecodeRuneInString(search[idx-lenZWJ:]); peek == zwj {
-					idx -= lenZWJ
-				}
-			}
-			idx -= 1
-			if idx < 0 {
-				continue
-			}
-			char = rune(search[idx])
-			if char > unicode.MaxASCII {
-				continue
-			}
-		}
+const zwj = '\ufe0f' // VARIATION SELECTOR-16 (emoji selector)
 
-		match, ok := tw.nodes[char]
-		if !ok {
+// replaceEmojis returns the wrapper holding the rewritten node, or nil if it has no emoji.
+// Text left as-is (see WithTextPresentation) doesn't need a wrapper, so it's rewritten in-place.
+// seen tracks inline SVG symbols already defined in the document.
+func (tw Twemoji) replaceEmojis(node *html.Node, seen map[string]bool) *html.Node {
+	search := node.Data
+	var span *html.Node
+	var text strings.Builder // regular text since the last emoji
+	var consumed int
+	for consumed < len(search) {
+		idx, m, _ := tw.next(search[consumed:], true)
+		if m == nil {
+			break
+		}
+		text.WriteString(search[consumed : consumed+idx])
+		consumed += idx + len(m.str)
+		if m.plain {
+			text.WriteString(m.elem)
 			continue
 		}
-		for _, m := range match {
-			if strings.HasPrefix(search[idx:], m.str) {
-				hit = true
-				if idx > 0 && consumed < idx {
-					// regular text before the emoji
-					emit(&html.Node{
-						Type: html.TextNode,
-						Data: search[consumed:idx],
-					}, idx)
-				}
-				// actual emoji
-				clone := *m.node
-				skip = idx + len(m.str)
-				emit(&clone, skip)
-				continue scan
-			}
+		if span == nil {
+			span = tw.wrapper()
+		}
+		if text.Len() > 0 {
+			// regular text before the emoji
+			span.AppendChild(&html.Node{
+				Type: html.TextNode,
+				Data: text.String(),
+			})
+			text.Reset()
+		}
+		// actual emoji
+		if seen != nil {
+			span.AppendChild(inlineSVG(m.node, m.img, seen))
+		} else if m.node.FirstChild != nil {
+			span.AppendChild(cloneNode(m.node))
+		} else {
+			clone := *m.node
+			span.AppendChild(&clone)
 		}
 	}
-	if !hit {
+	if span == nil {
+		if consumed > 0 {
+			// only stripped text selectors, if anything
+			text.WriteString(search[consumed:])
+			node.Data = text.String()
+		}
 		return nil
 	}
 	// "leftovers"
-	if consumed < len(search) {
-		emit(&html.Node{
+	text.WriteString(search[consumed:])
+	if text.Len() > 0 {
+		span.AppendChild(&html.Node{
 			Type: html.TextNode,
-			Data: search[consumed:],
-		}, 0)
+			Data: text.String(),
+		})
 	}
 	return span
 }
 
-func replaceTextNodes(root *html.Node, do func(*html.Node) *html.Node) {
+// wrapper returns the element that holds replaced text.
+// Without a wrapper, it's a temporary container whose children get spliced into the parent.
+func (tw Twemoji) wrapper() *html.Node {
+	tag := tw.wrapTag
+	if tag == "" {
+		tag = "span"
+	}
+	return &html.Node{
+		Type:     html.ElementNode,
+		Data:     tag,
+		DataAtom: atom.Lookup([]byte(tag)),
+		Attr:     slices.Clone(tw.wrapAttrs),
+	}
+}
+
+func (tw Twemoji) replaceTextNodes(root *html.Node, seen map[string]bool) {
 	switch {
 	case root == nil:
 		return
 	case root.Type == html.TextNode:
-		if rewrite := do(root); rewrite != nil {
-			replaceChild(root.Parent, root, rewrite)
+		if rewrite := tw.replaceEmojis(root, seen); rewrite != nil {
+			tw.rewrite(root.Parent, root, rewrite)
 		}
 		return
 	}
-	for node := root.FirstChild; node != nil; node = node.NextSibling {
+	var next *html.Node
+	for node := root.FirstChild; node != nil; node = next {
+		next = node.NextSibling
 		switch node.Type {
 		case html.TextNode:
-			if rewrite := do(node); rewrite != nil {
-				replaceChild(root, node, rewrite)
-				continue
+			if rewrite := tw.replaceEmojis(node, seen); rewrite != nil {
+				tw.rewrite(root, node, rewrite)
+			}
+		case html.ElementNode:
+			if node.FirstChild != nil && !tw.skips(node.Data, node.Attr) {
+				tw.replaceTextNodes(node, seen)
 			}
 		default:
 			if node.FirstChild != nil {
-				replaceTextNodes(node, do)
+				tw.replaceTextNodes(node, seen)
 			}
 		}
 	}
 }
 
+func (tw Twemoji) rewrite(parent, target, wrapper *html.Node) {
+	if tw.wrapTag == "" {
+		spliceChildren(parent, target, wrapper)
+		return
+	}
+	replaceChild(parent, target, wrapper)
+}
+
 func replaceChild(parent, target, replace *html.Node) bool {
 	if parent == nil || target == nil || replace == nil {
 		return false
@@ -156,6 +205,7 @@ func replaceChild(parent, target, replace *html.Node) bool {
 			if prev := n.PrevSibling; prev != nil {
 				prev.NextSibling = replace
 			}
+			replace.Parent = n.Parent
 			replace.PrevSibling = n.PrevSibling
 			if next := n.NextSibling; next != nil {
 				next.PrevSibling = replace
@@ -166,3 +216,16 @@ func replaceChild(parent, target, replace *html.Node) bool {
 	}
 	return false
 }
+
+// spliceChildren replaces target with the children of from.
+func spliceChildren(parent, target, from *html.Node) bool {
+	if parent == nil || target == nil || from == nil || target.Parent != parent {
+		return false
+	}
+	for child := from.FirstChild; child != nil; child = from.FirstChild {
+		from.RemoveChild(child)
+		parent.InsertBefore(child, target)
+	}
+	parent.RemoveChild(target)
+	return true
+}
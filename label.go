@@ -0,0 +1,113 @@
+package emojify
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/language"
+)
+
+// WithAriaLabel labels images with the emoji's name for screen readers, using aria-label and role="img".
+// Inline SVG images are always labeled, with the emoji itself by default.
+// See [WithLocale] for the language of names.
+func WithAriaLabel() Option {
+	return func(t *Twemoji) {
+		t.ariaLabel = true
+	}
+}
+
+// WithTitle gives images the emoji's name as a title, shown as a tooltip.
+// See [WithLocale] for the language of names.
+func WithTitle() Option {
+	return func(t *Twemoji) {
+		t.title = true
+	}
+}
+
+// WithAltName uses the emoji's name as the alt text of images, instead of the emoji itself.
+// Such images are still recognized by [Twemoji.Unreplace].
+// See [WithLocale] for the language of names.
+func WithAltName() Option {
+	return func(t *Twemoji) {
+		t.altName = true
+	}
+}
+
+// WithLocale specifies the language of emoji names given by [WithAriaLabel], [WithTitle], and [WithAltName].
+// Default is English, which is also used for names missing from the given language.
+func WithLocale(tag language.Tag) Option {
+	return func(t *Twemoji) {
+		t.locale = tag
+	}
+}
+
+// label returns the name of e for display in the configured locale, or e itself if it has no name.
+func (tw Twemoji) label(e Emoji) string {
+	if a, ok := locales().annotation(e.Text, tw.locale); ok && a.name != "" {
+		return a.name
+	}
+	if e.Name == "" {
+		return e.Text
+	}
+	return e.Name
+}
+
+// labelAttrs returns the title and aria-label attributes of e's <img>.
+func (tw Twemoji) labelAttrs(e Emoji) []html.Attribute {
+	var attrs []html.Attribute
+	if tw.title {
+		attrs = append(attrs, html.Attribute{Key: "title", Val: tw.label(e)})
+	}
+	if tw.ariaLabel {
+		attrs = append(attrs,
+			html.Attribute{Key: "role", Val: "img"},
+			html.Attribute{Key: "aria-label", Val: tw.label(e)},
+		)
+	}
+	return attrs
+}
+
+// unlabel returns the emoji an image was labeled with, given its alt text and aria-label.
+// Labels are matched against emoji names only if byName is set.
+func (tw Twemoji) unlabel(byName bool, labels ...string) (string, bool) {
+	for _, label := range labels {
+		if label == "" {
+			continue
+		}
+		if _, ok := tw.Lookup(label); ok {
+			return label, true
+		}
+		if !byName {
+			continue
+		}
+		if i, ok := names()[strings.ToLower(label)]; ok {
+			return twemojiData[i].str, true
+		}
+		if emoji, ok := locales().byName(label, tw.locale); ok {
+			return emoji, true
+		}
+	}
+	for _, label := range labels {
+		if label != "" {
+			return label, false
+		}
+	}
+	return "", false
+}
+
+// names indexes twemojiData by lowercase name, preferring fully-qualified emoji.
+var names = sync.OnceValue(func() map[string]int {
+	index := make(map[string]int, len(twemojiData))
+	for i, item := range twemojiData {
+		key := strings.ToLower(item.name)
+		if key == "" {
+			continue
+		}
+		if j, ok := index[key]; ok && twemojiData[j].status == FullyQualified {
+			continue
+		}
+		index[key] = i
+	}
+	return index
+})
@@ -0,0 +1,69 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestLabels(t *testing.T) {
+	tw := New(WithCDN("/t/"), WithAriaLabel(), WithTitle())
+	want := `<img draggable="false" class="emoji" src="/t/svg/1f600.svg" width="72" height="72" alt="😀" title="grinning face" role="img" aria-label="grinning face"/>`
+	if got := tw.Replace("😀"); got != want {
+		t.Errorf("Replace →\n got: %q\nwant: %q", got, want)
+	}
+
+	tw = New(WithCDN("/t/"), WithAltName())
+	want = `<img draggable="false" class="emoji" src="/t/svg/1f600.svg" width="72" height="72" alt="grinning face"/>`
+	if got := tw.Replace("😀"); got != want {
+		t.Errorf("alt name →\n got: %q\nwant: %q", got, want)
+	}
+	got, err := tw.Unreplace(tw.Replace("hi 😀 🇯🇵"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "hi 😀 🇯🇵" {
+		t.Errorf("Unreplace → %q", got)
+	}
+}
+
+func TestEmojiAttrs(t *testing.T) {
+	tw := New(WithCDN("/t/"), WithEmojiAttrs(func(e Emoji, defaults []html.Attribute) []html.Attribute {
+		return append(defaults, html.Attribute{Key: "data-group", Val: e.Group})
+	}))
+	if got := tw.Replace("😀"); !strings.Contains(got, `data-group="Smileys &amp; Emotion"`) {
+		t.Errorf("missing attribute: %q", got)
+	}
+}
+
+func TestInlineSVGLabels(t *testing.T) {
+	tw := New(WithFormat(InlineSVG), WithAssets(testAssets()), WithAriaLabel(), WithTitle())
+	doc, err := html.Parse(strings.NewReader("<p>🌎🌎</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.ReplaceHTML(doc)
+	var buf strings.Builder
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if n := strings.Count(got, `aria-label="globe showing Americas"`); n != 2 {
+		t.Errorf("expected 2 labels, got %d: %s", n, got)
+	}
+	if n := strings.Count(got, `<title>globe showing Americas</title>`); n != 2 {
+		t.Errorf("expected 2 titles, got %d: %s", n, got)
+	}
+	if !strings.Contains(got, `<title>globe showing Americas</title><symbol`) {
+		t.Errorf("title inside of symbol: %s", got)
+	}
+
+	text, err := tw.Unreplace(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "<p>🌎🌎</p>") {
+		t.Errorf("Unreplace → %q", text)
+	}
+}
@@ -0,0 +1,131 @@
+package emojify
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// locale is the CLDR annotations of a language, generated from script/data/cldr.
+type locale struct {
+	tag         string
+	annotations map[string]annotation // by emoji text without emoji presentation selectors
+}
+
+type annotation struct {
+	name     string
+	keywords []string
+}
+
+// localeSet matches languages to the available locales. English is built-in.
+type localeSet struct {
+	matcher language.Matcher
+	locales []locale // aligned with the matcher's tags, after English
+
+	namesOnce sync.Once
+	names     []map[string]string // lowercase name → emoji, by locale
+}
+
+func newLocaleSet(data []locale) *localeSet {
+	tags := []language.Tag{language.English}
+	locales := make([]locale, 0, len(data))
+	for _, loc := range data {
+		tag, err := language.Parse(loc.tag)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		locales = append(locales, loc)
+	}
+	return &localeSet{
+		matcher: language.NewMatcher(tags),
+		locales: locales,
+	}
+}
+
+var locales = sync.OnceValue(func() *localeSet {
+	return newLocaleSet(localeData)
+})
+
+// match returns the index of the locale best matching tag.
+// It returns false for English, or if no locale matches.
+func (ls *localeSet) match(tag language.Tag) (int, bool) {
+	if tag == language.Und || len(ls.locales) == 0 {
+		return 0, false
+	}
+	_, i, conf := ls.matcher.Match(tag)
+	if conf == language.No || i == 0 {
+		return 0, false
+	}
+	return i - 1, true
+}
+
+// annotation returns the annotation of emoji in the locale best matching tag.
+func (ls *localeSet) annotation(emoji string, tag language.Tag) (annotation, bool) {
+	i, ok := ls.match(tag)
+	if !ok {
+		return annotation{}, false
+	}
+	a, ok := ls.locales[i].annotations[strings.ReplaceAll(emoji, string(zwj), "")]
+	return a, ok
+}
+
+// byName returns the emoji with the given name in the locale best matching tag.
+func (ls *localeSet) byName(name string, tag language.Tag) (string, bool) {
+	i, ok := ls.match(tag)
+	if !ok {
+		return "", false
+	}
+	ls.namesOnce.Do(func() {
+		ls.names = make([]map[string]string, len(ls.locales))
+		for j, loc := range ls.locales {
+			ls.names[j] = make(map[string]string, len(loc.annotations))
+			for emoji, a := range loc.annotations {
+				if a.name != "" {
+					ls.names[j][strings.ToLower(a.name)] = emoji
+				}
+			}
+		}
+	})
+	emoji, ok := ls.names[i][strings.ToLower(name)]
+	if !ok {
+		return "", false
+	}
+	// annotations are keyed without emoji presentation selectors, prefer the form with an image
+	if e, ok := lookup(emoji); ok {
+		return e.Text, true
+	}
+	return emoji, true
+}
+
+// Languages returns the languages with localized emoji names and keywords, including English.
+func Languages() []language.Tag {
+	ls := locales()
+	tags := []language.Tag{language.English}
+	for _, loc := range ls.locales {
+		tags = append(tags, language.Make(loc.tag))
+	}
+	return tags
+}
+
+// Name returns the CLDR short name of emoji in the language best matching tag,
+// such as "grinning face" in English. It falls back to English if no localized name is available,
+// and returns "" for unknown emoji. See [Languages] for the available languages.
+func (tw Twemoji) Name(emoji string, tag language.Tag) string {
+	if a, ok := locales().annotation(emoji, tag); ok && a.name != "" {
+		return a.name
+	}
+	e, _ := tw.Lookup(emoji)
+	return e.Name
+}
+
+// Keywords returns the CLDR search keywords of emoji in the language best matching tag,
+// falling back to English.
+func (tw Twemoji) Keywords(emoji string, tag language.Tag) []string {
+	if a, ok := locales().annotation(emoji, tag); ok && len(a.keywords) > 0 {
+		return a.keywords
+	}
+	e, _ := tw.Lookup(emoji)
+	return e.Keywords
+}
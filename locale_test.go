@@ -0,0 +1,126 @@
+package emojify
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// testLocales replaces the generated locales with a small fixture for the duration of the test.
+func testLocales(t *testing.T) {
+	t.Helper()
+	old := locales
+	fixture := newLocaleSet([]locale{
+		{tag: "ja", annotations: map[string]annotation{
+			"😀": {name: "にっこり笑う", keywords: []string{"顔", "笑う"}},
+			"❤": {name: "赤いハート", keywords: []string{"ハート"}},
+		}},
+		{tag: "de", annotations: map[string]annotation{
+			"😀": {name: "grinsendes Gesicht", keywords: []string{"Gesicht", "lol"}},
+		}},
+	})
+	locales = func() *localeSet { return fixture }
+	t.Cleanup(func() { locales = old })
+}
+
+func TestName(t *testing.T) {
+	testLocales(t)
+	tw := New()
+	tests := []struct {
+		emoji string
+		tag   language.Tag
+		want  string
+	}{
+		{"😀", language.English, "grinning face"},
+		{"😀", language.Japanese, "にっこり笑う"},
+		{"😀", language.MustParse("de-AT"), "grinsendes Gesicht"},
+		{"❤️", language.Japanese, "赤いハート"},
+		{"🌎", language.Japanese, "globe showing Americas"}, // fallback
+		{"😀", language.French, "grinning face"},
+		{"nope", language.Japanese, ""},
+	}
+	for _, test := range tests {
+		if got := tw.Name(test.emoji, test.tag); got != test.want {
+			t.Errorf("Name(%q, %v) = %q, want %q", test.emoji, test.tag, got, test.want)
+		}
+	}
+	if got := tw.Keywords("😀", language.German); !slices.Equal(got, []string{"Gesicht", "lol"}) {
+		t.Errorf("Keywords = %q", got)
+	}
+	if langs := Languages(); len(langs) != 3 {
+		t.Errorf("Languages() = %v", langs)
+	}
+}
+
+func TestLocalizedLabels(t *testing.T) {
+	testLocales(t)
+	tw := New(WithCDN("/t/"), WithAltName(), WithLocale(language.Japanese))
+	want := `<img draggable="false" class="emoji" src="/t/svg/1f600.svg" width="72" height="72" alt="にっこり笑う"/>`
+	if got := tw.Replace("😀"); got != want {
+		t.Errorf("Replace →\n got: %q\nwant: %q", got, want)
+	}
+	got, err := tw.Unreplace(tw.Replace("😀 ❤ 🌎"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "😀 ❤ 🌎" {
+		t.Errorf("Unreplace → %q", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	testLocales(t)
+	tw := New(WithCustomEmoji(CustomEmoji{Name: "partyparrot", URL: "/parrot.gif"}))
+
+	has := func(found []Emoji, text string) bool {
+		return slices.ContainsFunc(found, func(e Emoji) bool { return e.Text == text })
+	}
+	if found := tw.Search("Grinning"); !has(found, "😀") || has(found, "❤️") {
+		t.Errorf("Search(grinning) = %v", found)
+	}
+	if found := tw.Search("ハート"); len(found) != 0 {
+		t.Errorf("Search(ハート) without language = %v", found)
+	}
+	if found := tw.Search("ハート", InLanguage(language.Japanese)); !has(found, "❤️") {
+		t.Errorf("Search(ハート, ja) = %v", found)
+	}
+	if found := tw.Search("parrot"); !has(found, ":partyparrot:") {
+		t.Errorf("Search(parrot) = %v", found)
+	}
+	// one result per emoji
+	seen := make(map[string]bool)
+	for _, e := range tw.Search("heart") {
+		key := strings.ReplaceAll(e.Text, "\ufe0f", "")
+		if seen[key] {
+			t.Errorf("duplicate result: %q", e.Text)
+		}
+		seen[key] = true
+	}
+	if found := tw.Search(" "); found != nil {
+		t.Errorf("Search of blank query = %v", found)
+	}
+}
+
+func TestLocaleData(t *testing.T) {
+	if len(localeData) == 0 {
+		t.Fatal("localeData is empty: run script/gen.sh")
+	}
+	tw := New()
+	tests := []struct {
+		tag  language.Tag
+		want string
+	}{
+		{language.Japanese, "サムズアップ"},
+		{language.German, "Daumen hoch"},
+	}
+	for _, test := range tests {
+		if got := tw.Name("👍", test.tag); got != test.want {
+			t.Errorf("Name(👍, %v) = %q, want %q", test.tag, got, test.want)
+		}
+	}
+	if langs := Languages(); !slices.Contains(langs, language.Japanese) {
+		t.Errorf("Languages() = %v", langs)
+	}
+}
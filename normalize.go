@@ -0,0 +1,170 @@
+package emojify
+
+import (
+	"cmp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// textSelector is VARIATION SELECTOR-15, which requests text presentation of the preceding character.
+const textSelector = '\ufe0e'
+
+// WithTextPresentation leaves emoji followed by U+FE0E (text presentation selector), such as ©︎, as text.
+// By default, they are replaced like any other emoji.
+func WithTextPresentation() Option {
+	return func(t *Twemoji) {
+		t.textPresentation = true
+	}
+}
+
+// WithStripTextSelector removes the U+FE0E of emoji left as text, see [WithTextPresentation].
+// It implies WithTextPresentation.
+func WithStripTextSelector() Option {
+	return func(t *Twemoji) {
+		t.textPresentation = true
+		t.stripSelector = true
+	}
+}
+
+// WithEmojiPresentation only replaces characters displayed as emoji by default, such as 😀,
+// or followed by U+FE0F (emoji presentation selector), such as ©️.
+// Characters displayed as text by default, such as © ® ™ ↔, are left as text. It implies [WithTextPresentation].
+func WithEmojiPresentation() Option {
+	return func(t *Twemoji) {
+		t.textPresentation = true
+		t.emojiPresentation = true
+	}
+}
+
+// WithAllowCodepoints replaces emoji beginning with the given code points even if [WithEmojiPresentation]
+// would leave them as text, such as '❤' for a bare ❤.
+func WithAllowCodepoints(cps ...rune) Option {
+	return func(t *Twemoji) {
+		t.allow = addRunes(t.allow, cps)
+	}
+}
+
+// WithDenyCodepoints never replaces emoji beginning with the given code points, such as '©' for © and ©️.
+func WithDenyCodepoints(cps ...rune) Option {
+	return func(t *Twemoji) {
+		t.deny = addRunes(t.deny, cps)
+	}
+}
+
+func addRunes(set map[rune]bool, runes []rune) map[rune]bool {
+	if set == nil {
+		set = make(map[rune]bool, len(runes))
+	}
+	for _, r := range runes {
+		set[r] = true
+	}
+	return set
+}
+
+// presents reports whether item should be replaced, according to the presentation options.
+// Aliases such as shortcodes are always replaced.
+func (tw Twemoji) presents(item resource) bool {
+	if item.text != "" {
+		return true
+	}
+	head, _ := utf8.DecodeRuneInString(item.str)
+	if tw.deny[head] {
+		return false
+	}
+	// unqualified emoji begin with a character displayed as text by default
+	if tw.emojiPresentation && item.status == Unqualified && !tw.allow[head] {
+		return false
+	}
+	return true
+}
+
+// textPresentations returns the text presentation sequences of single-character emoji in items:
+// rendered as text with [WithTextPresentation], otherwise aliases of the emoji.
+func (tw Twemoji) textPresentations(items []resource) []resource {
+	seen := make(map[string]bool)
+	var seqs []resource
+	for _, item := range items {
+		base := strings.TrimSuffix(item.str, string(zwj))
+		if item.plain || item.status == Component || utf8.RuneCountInString(base) != 1 || seen[base] {
+			continue
+		}
+		seen[base] = true
+		str := base + string(textSelector)
+		if tw.textPresentation {
+			text := str
+			if tw.stripSelector {
+				text = base
+			}
+			seqs = append(seqs, plainResource(str, text))
+			continue
+		}
+		item.text = item.str
+		item.str = str
+		seqs = append(seqs, item)
+	}
+	return seqs
+}
+
+// plainResource replaces str with text, rather than an image.
+func plainResource(str, text string) resource {
+	return resource{
+		str:   str,
+		node:  &html.Node{Type: html.TextNode, Data: text},
+		elem:  text,
+		plain: true,
+	}
+}
+
+// Normalize returns a copy of s with emojis converted to the given form:
+// [FullyQualified], [MinimallyQualified], or [Unqualified].
+// Fully-qualified emoji have every emoji presentation selector (U+FE0F) given by Unicode's emoji-test.txt,
+// minimally-qualified emoji keep only the one following their first character, if any,
+// and unqualified emoji have none. Emoji whose first character doesn't take a selector, such as 🧘‍♀️,
+// have no unqualified form and become minimally-qualified instead. Other forms leave s unchanged.
+// Shortcodes, custom emoji, and text presentation sequences (see [WithTextPresentation]) are left as-is.
+func (tw Twemoji) Normalize(s string, form Status) string {
+	if tw.replacer == nil {
+		return defaults().Normalize(s, form)
+	}
+	switch form {
+	case FullyQualified, MinimallyQualified, Unqualified:
+	default:
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for len(s) > 0 {
+		idx, m, _ := tw.next(s, true)
+		b.WriteString(s[:idx])
+		if m == nil {
+			break
+		}
+		switch {
+		case m.text != "", m.plain:
+			b.WriteString(m.str)
+		case m.status == FullyQualified, m.status == MinimallyQualified, m.status == Unqualified:
+			b.WriteString(qualify(cmp.Or(m.qualified, m.str), form))
+		default:
+			b.WriteString(m.str)
+		}
+		s = s[idx+len(m.str):]
+	}
+	return b.String()
+}
+
+// qualify converts the fully-qualified emoji to the given form.
+func qualify(emoji string, form Status) string {
+	switch form {
+	case Unqualified:
+		return strings.ReplaceAll(emoji, string(zwj), "")
+	case MinimallyQualified:
+		_, size := utf8.DecodeRuneInString(emoji)
+		if strings.HasPrefix(emoji[size:], string(zwj)) {
+			size += len(string(zwj))
+		}
+		return emoji[:size] + strings.ReplaceAll(emoji[size:], string(zwj), "")
+	}
+	return emoji
+}
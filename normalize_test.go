@@ -0,0 +1,129 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestNormalize(t *testing.T) {
+	const in = "hi ❤ 🏌‍♂ 👁‍🗨 #⃣ ©︎ 😀 👍🏽 :smile:"
+	tests := []struct {
+		form Status
+		want string
+	}{
+		{FullyQualified, "hi ❤️ 🏌️‍♂️ 👁️‍🗨️ #️⃣ ©︎ 😀 👍🏽 :smile:"},
+		{MinimallyQualified, "hi ❤️ 🏌️‍♂ 👁️‍🗨 #️⃣ ©︎ 😀 👍🏽 :smile:"},
+		{Unqualified, "hi ❤ 🏌‍♂ 👁‍🗨 #⃣ ©︎ 😀 👍🏽 :smile:"},
+		{NonStandard, in},
+	}
+	tw := New(WithShortcodes(GitHub))
+	for _, test := range tests {
+		if got := tw.Normalize(in, test.form); got != test.want {
+			t.Errorf("Normalize(%v)\n got: %q\nwant: %q", test.form, got, test.want)
+		}
+	}
+
+	// every form converts to a form listed by Unicode
+	index := catalog()
+	for _, item := range twemojiData {
+		if item.qualified == "" {
+			continue
+		}
+		for _, form := range []Status{MinimallyQualified, Unqualified} {
+			got := qualify(item.qualified, form)
+			// some emoji, such as 🧘‍♀️, have no unqualified form
+			i, ok := index[got]
+			if !ok || (form == MinimallyQualified && twemojiData[i].status == Unqualified) {
+				t.Errorf("qualify(%q, %v) = %q, not listed", item.qualified, form, got)
+			}
+		}
+	}
+}
+
+func TestQualifiedForms(t *testing.T) {
+	tw := New()
+	// every form of an emoji has the same image
+	for _, form := range []string{"🏌️‍♂️", "🏌‍♂️", "🏌️‍♂", "🏌‍♂"} {
+		got := tw.Replace(form)
+		if strings.Count(got, "<img") != 1 || !strings.Contains(got, "1f3cc-fe0f-200d-2642-fe0f.svg") || !strings.HasSuffix(got, "/>") {
+			t.Errorf("Replace(%q) = %s", form, got)
+		}
+	}
+	for _, form := range []string{"❤️", "❤", "❤︎"} {
+		if got := tw.Replace(form); !strings.Contains(got, "2764.svg") || !strings.HasSuffix(got, "/>") {
+			t.Errorf("Replace(%q) = %s", form, got)
+		}
+	}
+
+	tp := New(WithTextPresentation())
+	got := tp.Replace("©︎ © ❤︎ ❤️")
+	if !strings.HasPrefix(got, "©︎ <img") || !strings.Contains(got, "/> ❤︎ <img") || strings.Count(got, "<img") != 2 {
+		t.Errorf("WithTextPresentation: Replace = %s", got)
+	}
+	if tp.OnlyEmoji("©︎") {
+		t.Error("WithTextPresentation: OnlyEmoji(©︎) = true")
+	}
+	var b strings.Builder
+	if _, err := tp.WriteString(&b, "a©︎b"); err != nil || b.String() != "a©︎b" {
+		t.Errorf("WithTextPresentation: WriteString = %q, %v", b.String(), err)
+	}
+}
+
+func TestEmojiPresentation(t *testing.T) {
+	const in = "© 2024 ©️ ©︎ ↔ ❤ ❤️ 😀 ✌ ✌🏻 #⃣ #️⃣"
+	tw := New(WithEmojiPresentation())
+	if got, want := foundEmoji(tw, in), "©️ ❤️ 😀 ✌🏻 #️⃣"; got != want {
+		t.Errorf("WithEmojiPresentation: found %q, want %q", got, want)
+	}
+	if got := tw.Replace(in); !strings.HasPrefix(got, "© 2024 <img") || !strings.Contains(got, "/> ©︎ ↔ ❤ <img") {
+		t.Errorf("WithEmojiPresentation: Replace = %s", got)
+	}
+	if tw.OnlyEmoji("❤") {
+		t.Error("WithEmojiPresentation: OnlyEmoji(❤) = true")
+	}
+
+	tw = New(WithEmojiPresentation(), WithStripTextSelector(), WithAllowCodepoints('❤'), WithDenyCodepoints('©'))
+	if got, want := foundEmoji(tw, in), "❤ ❤️ 😀 ✌🏻 #️⃣"; got != want {
+		t.Errorf("allow/deny: found %q, want %q", got, want)
+	}
+	if got := tw.Replace("↔︎ ©︎"); got != "↔ ©︎" {
+		t.Errorf("WithStripTextSelector: Replace = %q", got)
+	}
+	tests := []struct {
+		tw       Twemoji
+		in, want string
+	}{
+		{tw, "<p>↔︎ ©️</p>", "<p>↔ ©️</p>"},
+		{New(WithTextPresentation()), "<p>a ©︎ b</p>", "<p>a ©︎ b</p>"},
+		{New(WithoutGroups("Symbols")), "<p>a © b</p>", "<p>a © b</p>"},
+	}
+	for _, test := range tests {
+		doc, err := html.Parse(strings.NewReader(test.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.tw.ReplaceHTML(doc)
+		var buf strings.Builder
+		if err := html.Render(&buf, doc); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); !strings.Contains(got, test.want) {
+			t.Errorf("ReplaceHTML(%s) = %s, want %s", test.in, got, test.want)
+		}
+	}
+
+	// the rest of a sequence left as text isn't replaced
+	for _, in := range []string{"🇯🇵", "🏳‍🌈"} {
+		tw := New(WithEmojiPresentation(), WithDenyCodepoints('🇯'))
+		if got := tw.Replace(in); got != in {
+			t.Errorf("Replace(%q) = %s", in, got)
+		}
+	}
+	// shortcodes are always replaced
+	tw = New(WithEmojiPresentation(), WithShortcodes(CLDR))
+	if got := tw.Replace(":copyright:"); !strings.Contains(got, "a9.svg") {
+		t.Errorf("WithEmojiPresentation: Replace(:copyright:) = %s", got)
+	}
+}
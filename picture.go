@@ -0,0 +1,75 @@
+package emojify
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SizedProvider is a [Provider] with PNG images in multiple resolutions, used by the [Srcset] format.
+type SizedProvider interface {
+	Provider
+	// Sizes returns the available widths of PNG images, in pixels.
+	Sizes() []int
+	// SizedPath returns the path of the PNG image for emoji with the given width, relative to the CDN.
+	SizedPath(emoji string, size int) string
+}
+
+// WithSizes specifies the sizes attribute of [Srcset] images, such as "(min-width: 800px) 2em, 1em".
+// Default is "72px".
+func WithSizes(sizes string) Option {
+	return func(t *Twemoji) {
+		t.sizes = sizes
+	}
+}
+
+// picture wraps img in a <picture> element with an SVG <source>.
+func (tw Twemoji) picture(emoji string, img *html.Node) (*html.Node, error) {
+	src := tw.provider.Path(emoji, SVG)
+	if src == "" {
+		return img, nil
+	}
+	href, err := tw.href(src)
+	if err != nil {
+		return nil, err
+	}
+	picture := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "picture",
+		DataAtom: atom.Picture,
+	}
+	picture.AppendChild(&html.Node{
+		Type:     html.ElementNode,
+		Data:     "source",
+		DataAtom: atom.Source,
+		Attr: []html.Attribute{
+			{Key: "type", Val: "image/svg+xml"},
+			{Key: "srcset", Val: href},
+		},
+	})
+	picture.AppendChild(img)
+	return picture, nil
+}
+
+// srcset returns the srcset of emoji's PNG images, or just href if the provider isn't a [SizedProvider].
+func (tw Twemoji) srcset(emoji, href string) (string, error) {
+	sized, ok := tw.provider.(SizedProvider)
+	if !ok {
+		return href + " 72w", nil
+	}
+	var candidates []string
+	for _, size := range sized.Sizes() {
+		src := sized.SizedPath(emoji, size)
+		if src == "" {
+			continue
+		}
+		href, err := tw.href(src)
+		if err != nil {
+			return "", err
+		}
+		candidates = append(candidates, href+" "+strconv.Itoa(size)+"w")
+	}
+	return strings.Join(candidates, ", "), nil
+}
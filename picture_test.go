@@ -0,0 +1,54 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestPicture(t *testing.T) {
+	tw := New(WithFormat(Picture), WithCDN("/twemoji/"))
+	want := `hi <picture><source type="image/svg+xml" srcset="/twemoji/svg/1f30e.svg"/>` +
+		`<img draggable="false" class="emoji" src="/twemoji/72x72/1f30e.png" width="72" height="72" alt="🌎"/></picture>`
+	if got := tw.Replace("hi 🌎"); got != want {
+		t.Errorf("Replace →\n got: %q\nwant: %q", got, want)
+	}
+
+	doc, err := html.Parse(strings.NewReader("<p>🌎🌎</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.ReplaceHTML(doc)
+	var buf strings.Builder
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "<source "); n != 2 {
+		t.Errorf("expected 2 sources, got %d: %s", n, buf.String())
+	}
+
+	got, err := tw.Unreplace(tw.Replace("hi 🌎!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "hi 🌎!" {
+		t.Errorf("Unreplace → %q", got)
+	}
+}
+
+func TestSrcset(t *testing.T) {
+	tw := New(WithFormat(Srcset), WithProvider(NotoProvider), WithCDN("/noto/"), WithSizes("1em"))
+	want := `<img draggable="false" class="emoji" src="/noto/png/72/emoji_u1f30e.png" ` +
+		`srcset="/noto/png/32/emoji_u1f30e.png 32w, /noto/png/72/emoji_u1f30e.png 72w, /noto/png/128/emoji_u1f30e.png 128w, /noto/png/512/emoji_u1f30e.png 512w" ` +
+		`sizes="1em" width="72" height="72" alt="🌎"/>`
+	if got := tw.Replace("🌎"); got != want {
+		t.Errorf("noto →\n got: %q\nwant: %q", got, want)
+	}
+
+	tw = New(WithFormat(Srcset), WithCDN("/twemoji/"))
+	want = `<img draggable="false" class="emoji" src="/twemoji/72x72/1f30e.png" srcset="/twemoji/72x72/1f30e.png 72w" sizes="72px" width="72" height="72" alt="🌎"/>`
+	if got := tw.Replace("🌎"); got != want {
+		t.Errorf("twemoji →\n got: %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+package emojify
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Provider is a set of emoji images, such as Twemoji.
+type Provider interface {
+	// Emojis returns every emoji sequence with an image.
+	Emojis() []string
+	// Path returns the path of the image for emoji in the given format ([SVG] or [PNG]),
+	// relative to the CDN. It's also the path used to find the image in local assets (see [WithAssets]).
+	Path(emoji string, format Format) string
+	// CDN returns the default URL root of the images, including a trailing slash.
+	CDN() string
+}
+
+// WithProvider specifies the set of emoji images to use. Default is [TwemojiProvider].
+// Unless overridden by [WithCDN], the provider's CDN is used.
+func WithProvider(p Provider) Option {
+	return func(t *Twemoji) {
+		t.provider = p
+	}
+}
+
+var (
+	// TwemojiProvider is Twemoji, the default provider.
+	// Paths look like svg/1f600.svg and 72x72/1f600.png.
+	TwemojiProvider Provider = twemojiProvider{}
+	// NotoProvider is Google's Noto Emoji, a [SizedProvider] with 32, 72, 128, and 512 px PNG images.
+	// Paths look like svg/emoji_u1f600.svg and png/72/emoji_u1f600.png, with U+FE0F removed.
+	NotoProvider Provider = notoProvider{}
+	// OpenMojiProvider is OpenMoji, a [SizedProvider] with 72 and 618 px PNG images.
+	// Paths look like color/svg/1F600.svg and color/72x72/1F600.png, with fully-qualified sequences
+	// except for a lone U+FE0F following a single character. Its CDN is OpenMoji 15.0, so newer emoji are left as text.
+	OpenMojiProvider Provider = openMojiProvider{}
+)
+
+type twemojiProvider struct{}
+
+func (twemojiProvider) Emojis() []string {
+	emojis := make([]string, len(twemojiData))
+	for i, item := range twemojiData {
+		emojis[i] = item.str
+	}
+	return emojis
+}
+
+func (twemojiProvider) Path(emoji string, format Format) string {
+	index := catalog()
+	i, ok := index[emoji]
+	if !ok {
+		i, ok = index[strings.ReplaceAll(emoji, string(zwj), "")]
+	}
+	if !ok {
+		return ""
+	}
+	name := twemojiData[i].img
+	if format == PNG {
+		return "72x72/" + strings.TrimSuffix(name, ".svg") + ".png"
+	}
+	return "svg/" + name
+}
+
+func (twemojiProvider) CDN() string {
+	return OfficialCDN
+}
+
+type notoProvider struct{}
+
+// notoVersion is the newest Emoji version covered by Noto Emoji.
+// Its CDN follows the main branch, which covers every emoji known to this package.
+var notoVersion = EmojiVersion{15, 1}
+
+// Emojis returns the standard emoji up to Noto's Emoji version.
+func (notoProvider) Emojis() []string {
+	return standardEmojis(notoVersion)
+}
+
+func (p notoProvider) Path(emoji string, format Format) string {
+	if format == PNG {
+		return p.SizedPath(emoji, 72)
+	}
+	name := notoName(emoji)
+	if isFlag(emoji) {
+		return "third_party/region-flags/waved-svg/" + name + ".svg"
+	}
+	return "svg/" + name + ".svg"
+}
+
+func (notoProvider) Sizes() []int {
+	return []int{32, 72, 128, 512}
+}
+
+func (notoProvider) SizedPath(emoji string, size int) string {
+	return "png/" + strconv.Itoa(size) + "/" + notoName(emoji) + ".png"
+}
+
+func (notoProvider) CDN() string {
+	return "https://cdn.jsdelivr.net/gh/googlefonts/noto-emoji@main/"
+}
+
+func notoName(emoji string) string {
+	return "emoji_u" + hexName(strings.ReplaceAll(emoji, string(zwj), ""), "_", false)
+}
+
+type openMojiProvider struct{}
+
+// openMojiVersion is the newest Emoji version covered by the OpenMoji release used by its CDN.
+var openMojiVersion = EmojiVersion{15, 0}
+
+// Emojis returns the standard emoji up to OpenMoji's Emoji version.
+func (openMojiProvider) Emojis() []string {
+	return standardEmojis(openMojiVersion)
+}
+
+func (p openMojiProvider) Path(emoji string, format Format) string {
+	if format == PNG {
+		return p.SizedPath(emoji, 72)
+	}
+	return "color/svg/" + openMojiName(emoji) + ".svg"
+}
+
+func (openMojiProvider) Sizes() []int {
+	return []int{72, 618}
+}
+
+func (openMojiProvider) SizedPath(emoji string, size int) string {
+	return "color/" + strconv.Itoa(size) + "x" + strconv.Itoa(size) + "/" + openMojiName(emoji) + ".png"
+}
+
+func (openMojiProvider) CDN() string {
+	return "https://cdn.jsdelivr.net/npm/openmoji@15.0.0/"
+}
+
+func openMojiName(emoji string) string {
+	if i, ok := catalog()[emoji]; ok && twemojiData[i].qualified != "" {
+		emoji = twemojiData[i].qualified
+	}
+	if base, ok := strings.CutSuffix(emoji, string(zwj)); ok && len([]rune(base)) == 1 {
+		emoji = base
+	}
+	return hexName(emoji, "-", true)
+}
+
+// standardEmojis returns the emoji listed by Unicode, up to the given Emoji version.
+func standardEmojis(max EmojiVersion) []string {
+	emojis := make([]string, 0, len(twemojiData))
+	for _, item := range twemojiData {
+		if item.status != NonStandard && item.version.Compare(max) <= 0 {
+			emojis = append(emojis, item.str)
+		}
+	}
+	return emojis
+}
+
+// hexName returns the code points of emoji in (at least 4 digit) hex, separated by sep.
+func hexName(emoji string, sep string, upper bool) string {
+	var b strings.Builder
+	for i, r := range []rune(emoji) {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		hex := strconv.FormatInt(int64(r), 16)
+		if upper {
+			hex = strings.ToUpper(hex)
+		}
+		if len(hex) < 4 {
+			hex = strings.Repeat("0", 4-len(hex)) + hex
+		}
+		b.WriteString(hex)
+	}
+	return b.String()
+}
+
+// isFlag reports whether emoji is a pair of regional indicators.
+func isFlag(emoji string) bool {
+	runes := []rune(emoji)
+	return len(runes) == 2 && isRegionalIndicator(runes[0]) && isRegionalIndicator(runes[1])
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= '\U0001F1E6' && r <= '\U0001F1FF'
+}
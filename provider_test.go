@@ -0,0 +1,59 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProviderPaths(t *testing.T) {
+	tests := []struct {
+		provider Provider
+		emoji    string
+		format   Format
+		want     string
+	}{
+		{TwemojiProvider, "😀", SVG, "svg/1f600.svg"},
+		{TwemojiProvider, "😀", PNG, "72x72/1f600.png"},
+		{TwemojiProvider, "❤️", SVG, "svg/2764.svg"},
+		{NotoProvider, "😀", SVG, "svg/emoji_u1f600.svg"},
+		{NotoProvider, "😀", PNG, "png/72/emoji_u1f600.png"},
+		{NotoProvider, "❤️", SVG, "svg/emoji_u2764.svg"},
+		{NotoProvider, "👩‍❤️‍👨", SVG, "svg/emoji_u1f469_200d_2764_200d_1f468.svg"},
+		{NotoProvider, "🇯🇵", SVG, "third_party/region-flags/waved-svg/emoji_u1f1ef_1f1f5.svg"},
+		{OpenMojiProvider, "😀", SVG, "color/svg/1F600.svg"},
+		{OpenMojiProvider, "😀", PNG, "color/72x72/1F600.png"},
+		{OpenMojiProvider, "❤", SVG, "color/svg/2764.svg"},
+		{OpenMojiProvider, "1⃣", SVG, "color/svg/0031-FE0F-20E3.svg"},
+	}
+	for _, test := range tests {
+		if got := test.provider.Path(test.emoji, test.format); got != test.want {
+			t.Errorf("%T.Path(%q, %v) = %q, want %q", test.provider, test.emoji, test.format, got, test.want)
+		}
+	}
+}
+
+func TestWithProvider(t *testing.T) {
+	noto := New(WithProvider(NotoProvider))
+	want := `<img draggable="false" class="emoji" src="https://cdn.jsdelivr.net/gh/googlefonts/noto-emoji@main/svg/emoji_u1f600.svg" width="72" height="72" alt="😀"/>`
+	if got := noto.Replace("😀"); got != want {
+		t.Errorf("noto →\n got: %q\nwant: %q", got, want)
+	}
+
+	custom := New(WithProvider(OpenMojiProvider), WithCDN("/static/openmoji/"), WithFormat(PNG))
+	want = `<img draggable="false" class="emoji" src="/static/openmoji/color/72x72/1F600.png" width="72" height="72" alt="😀"/>`
+	if got := custom.Replace("😀"); got != want {
+		t.Errorf("openmoji →\n got: %q\nwant: %q", got, want)
+	}
+
+	// lone regional indicators are Twemoji-only
+	if got := noto.Replace("🇦"); strings.Contains(got, "<img") {
+		t.Errorf("noto replaced a non-standard emoji: %q", got)
+	}
+	// OpenMoji 15.0 has no Emoji 15.1 images
+	if got := custom.Replace("🐦‍🔥"); strings.Contains(got, "<img") {
+		t.Errorf("openmoji replaced an Emoji 15.1 emoji: %q", got)
+	}
+	if got := custom.Replace("🫨"); !strings.Contains(got, "<img") {
+		t.Errorf("openmoji didn't replace an Emoji 15.0 emoji: %q", got)
+	}
+}
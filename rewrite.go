@@ -0,0 +1,126 @@
+package emojify
+
+import (
+	"errors"
+	"io"
+
+	"golang.org/x/net/html"
+)
+
+// RewriteHTML copies the HTML read from r to w, replacing emojis in text with twemoji images.
+// Unlike [Twemoji.ReplaceHTML], it works on a stream of tokens instead of a parsed tree,
+// so markup is copied byte-for-byte and only text is rewritten.
+// Text inside of code blocks and the like is left alone, see [WithSkipElements] and [WithSkipAttr].
+func (tw Twemoji) RewriteHTML(w io.Writer, r io.Reader) error {
+	if tw.replacer == nil {
+		return defaults().RewriteHTML(w, r)
+	}
+
+	var seen map[string]bool
+	if tw.fmt == InlineSVG {
+		seen = make(map[string]bool)
+	}
+	var (
+		z     = html.NewTokenizer(r)
+		open  []string // stack of open elements
+		skip  = -1     // index of the outermost skipped element in open
+		attrs []html.Attribute
+	)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return err
+			}
+			return nil
+		}
+
+		if tt == html.TextToken && skip == -1 {
+			if err := tw.writeText(w, string(z.Raw()), seen); err != nil {
+				return err
+			}
+			continue
+		}
+		// write before inspecting the tag, which lowercases it in place
+		if _, err := w.Write(z.Raw()); err != nil {
+			return err
+		}
+
+		switch tt {
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			if voidElements[tag] {
+				continue
+			}
+			open = append(open, tag)
+			if skip != -1 {
+				continue
+			}
+			attrs = attrs[:0]
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				attrs = append(attrs, html.Attribute{Key: string(key), Val: string(val)})
+			}
+			if tw.skips(tag, attrs) {
+				skip = len(open) - 1
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			i := len(open) - 1
+			for i >= 0 && open[i] != string(name) {
+				i--
+			}
+			if i < 0 {
+				continue
+			}
+			open = open[:i]
+			if skip >= len(open) {
+				skip = -1
+			}
+		}
+	}
+}
+
+// writeText writes text to w with emoji replaced.
+// If seen is non-nil, inline SVG symbols are defined once and reused.
+func (tw Twemoji) writeText(w io.Writer, text string, seen map[string]bool) error {
+	if seen == nil {
+		_, err := tw.replacer.WriteString(w, text)
+		return err
+	}
+	for len(text) > 0 {
+		idx, m, _ := tw.next(text, true)
+		if _, err := io.WriteString(w, text[:idx]); err != nil {
+			return err
+		}
+		if m == nil {
+			break
+		}
+		if err := html.Render(w, inlineSVG(m.node, m.img, seen)); err != nil {
+			return err
+		}
+		text = text[idx+len(m.str):]
+	}
+	return nil
+}
+
+// voidElements have no end tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"keygen": true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
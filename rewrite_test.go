@@ -0,0 +1,59 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewriteHTML(t *testing.T) {
+	img := Replace("👍")
+	table := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   `<P CLASS=x>hi 👍</P>`,
+			want: `<P CLASS=x>hi ` + img + `</P>`,
+		},
+		{
+			in:   `<!DOCTYPE html><p title='👍'>&amp; 👍<br>👍<img src=a.png alt="👍"> &#x1F44D;</p><!-- 👍 -->`,
+			want: `<!DOCTYPE html><p title='👍'>&amp; ` + img + `<br>` + img + `<img src=a.png alt="👍"> &#x1F44D;</p><!-- 👍 -->`,
+		},
+		{
+			in:   `<pre><code>👍<b>👍</b></code>👍</pre>👍`,
+			want: `<pre><code>👍<b>👍</b></code>👍</pre>` + img,
+		},
+		{
+			in:   `<script>if (a < b) { x = "👍</p>" }</script><style>p::after { content: "👍" }</style><p>👍`,
+			want: `<script>if (a < b) { x = "👍</p>" }</script><style>p::after { content: "👍" }</style><p>` + img,
+		},
+		{
+			in:   `<div data-emojify=off><p>👍</div><p>👍</p>`,
+			want: `<div data-emojify=off><p>👍</div><p>` + img + `</p>`,
+		},
+	}
+	for _, try := range table {
+		var buf strings.Builder
+		if err := RewriteHTML(&buf, strings.NewReader(try.in)); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != try.want {
+			t.Errorf("RewriteHTML(%q) →\n got: %q\nwant: %q", try.in, got, try.want)
+		}
+	}
+}
+
+func TestRewriteHTMLInlineSVG(t *testing.T) {
+	tw := New(WithFormat(InlineSVG), WithAssets(testAssets()))
+	var buf strings.Builder
+	if err := tw.RewriteHTML(&buf, strings.NewReader(`<p>🌎</p><p>🌎</p>`)); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if n := strings.Count(got, `<symbol id="emoji-1f30e"`); n != 1 {
+		t.Errorf("expected 1 symbol, got %d: %s", n, got)
+	}
+	if n := strings.Count(got, `<use href="#emoji-1f30e">`); n != 2 {
+		t.Errorf("expected 2 uses, got %d: %s", n, got)
+	}
+}
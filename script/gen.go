@@ -1,26 +1,45 @@
 package main
 
 import (
+	"bufio"
 	"cmp"
+	"encoding/xml"
+	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"golang.org/x/net/html"
 )
 
 var version string
 
+const (
+	emojiTestFile = "script/data/emoji-test.txt"
+	cldrDir       = "script/data/cldr"
+	shortcodesDir = "script/data/shortcodes"
+)
+
+var locales = flag.String("locales", "", "comma-separated CLDR locales for localized names and keywords, such as ja,de,pt")
+
 func main() {
-	version = os.Args[1]
+	flag.Parse()
+	version = flag.Arg(0)
 	files, err := os.ReadDir(filepath.Join("twemoji", "assets", "svg"))
 	if err != nil {
 		panic(err)
 	}
-	filenames := make([]string, 0, len(files)+len(aliases))
+	tests, err := parseEmojiTest(emojiTestFile)
+	if err != nil {
+		panic(err)
+	}
+
+	filenames := make([]string, 0, len(files))
 	for _, entry := range files {
 		if entry.IsDir() {
 			continue
@@ -31,10 +50,24 @@ func main() {
 		}
 		filenames = append(filenames, entry.Name())
 	}
+	aliases := formAliases(filenames, tests)
 	for alias := range aliases {
 		filenames = append(filenames, alias)
 	}
 	slices.SortFunc(filenames, properly)
+	english, err := parseAnnotations(cldrDir, "en")
+	if err != nil {
+		panic(err)
+	}
+
+	// fully-qualified forms, by text without VS16
+	qualified := make(map[string]string)
+	for text, test := range tests {
+		if test.status == "FullyQualified" {
+			qualified[stripVS16(text)] = text
+		}
+	}
+
 	data := make([]emojiData, 0, len(filenames))
 	for _, name := range filenames {
 		text := parseName(name)
@@ -45,12 +78,40 @@ func main() {
 		if alias, ok := aliases[info.img]; ok {
 			info.img = alias
 		}
+		if test, ok := tests[text]; ok {
+			info.test = test
+			if test.status != "FullyQualified" && test.status != "Component" {
+				info.qualified = qualified[stripVS16(text)]
+			}
+		}
+		info.keywords = english[stripVS16(text)].keywords
 		data = append(data, info)
 	}
-	writeCode(data)
+
+	shortcodes, err := parseShortcodes(shortcodesDir, data)
+	if err != nil {
+		panic(err)
+	}
+	shortcodes = append(shortcodes, cldrShortcodes(data)...)
+
+	var locs []locale
+	for _, tag := range strings.Split(*locales, ",") {
+		if tag = strings.TrimSpace(tag); tag == "" || tag == "en" {
+			continue
+		}
+		annotations, err := parseAnnotations(cldrDir, tag)
+		if err != nil {
+			panic(err)
+		}
+		if len(annotations) == 0 {
+			panic(fmt.Sprintf("no CLDR annotations for locale %q in %s", tag, cldrDir))
+		}
+		locs = append(locs, locale{tag: tag, annotations: annotations})
+	}
+	writeCode(data, shortcodes, locs)
 }
 
-func writeCode(emojis []emojiData) {
+func writeCode(emojis []emojiData, shortcodes []shortcode, locales []locale) {
 	fmt.Println("// Code generated by go generate; DO NOT EDIT.")
 	fmt.Println()
 	fmt.Println("package emojify")
@@ -60,15 +121,303 @@ func writeCode(emojis []emojiData) {
 	fmt.Println()
 	fmt.Println("var twemojiData = []resource{")
 	for _, emoji := range emojis {
-		fmt.Printf("\t{str: %q, img: %q},\n", emoji.str, emoji.img)
+		fmt.Printf("\t{str: %q, img: %q", emoji.str, emoji.img)
+		if emoji.name != "" {
+			fmt.Printf(", name: %q, group: %q, subgroup: %q", emoji.name, emoji.group, emoji.subgroup)
+			fmt.Printf(", status: %s, version: EmojiVersion{%d, %d}", emoji.status, emoji.major, emoji.minor)
+		}
+		if emoji.qualified != "" {
+			fmt.Printf(", qualified: %q", emoji.qualified)
+		}
+		if len(emoji.keywords) > 0 {
+			fmt.Printf(", keywords: %#v", emoji.keywords)
+		}
+		fmt.Println("},")
+	}
+	fmt.Println("}")
+	fmt.Println()
+	fmt.Println("var shortcodeData = []shortcode{")
+	for _, sc := range shortcodes {
+		fmt.Printf("\t{dialect: %s, code: %q, str: %q},\n", sc.dialect, sc.code, sc.str)
+	}
+	fmt.Println("}")
+	fmt.Println()
+	fmt.Println("var localeData = []locale{")
+	for _, loc := range locales {
+		fmt.Printf("\t{tag: %q, annotations: map[string]annotation{\n", strings.ReplaceAll(loc.tag, "_", "-"))
+		keys := slices.Sorted(maps.Keys(loc.annotations))
+		for _, key := range keys {
+			a := loc.annotations[key]
+			fmt.Printf("\t\t%q: {name: %q", key, a.name)
+			if len(a.keywords) > 0 {
+				fmt.Printf(", keywords: %#v", a.keywords)
+			}
+			fmt.Println("},")
+		}
+		fmt.Println("\t}},")
 	}
 	fmt.Println("}")
 }
 
+type locale struct {
+	tag         string
+	annotations map[string]annotation
+}
+
+type annotation struct {
+	name     string
+	keywords []string
+}
+
 type emojiData struct {
-	str  string
-	img  string
-	node *html.Node
+	str       string
+	img       string
+	node      *html.Node
+	keywords  []string
+	qualified string
+	test
+}
+
+// test is an entry of emoji-test.txt
+type test struct {
+	name     string
+	group    string
+	subgroup string
+	status   string
+	major    int
+	minor    int
+}
+
+var statuses = map[string]string{
+	"component":           "Component",
+	"fully-qualified":     "FullyQualified",
+	"minimally-qualified": "MinimallyQualified",
+	"unqualified":         "Unqualified",
+}
+
+// parseEmojiTest reads Unicode's emoji-test.txt, keyed by emoji text.
+// Format: code points; status # emoji EX.Y name
+func parseEmojiTest(path string) (map[string]test, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tests := make(map[string]test)
+	var group, subgroup string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if after, ok := strings.CutPrefix(line, "# group: "); ok {
+			group = after
+			continue
+		}
+		if after, ok := strings.CutPrefix(line, "# subgroup: "); ok {
+			subgroup = after
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cps, rest, ok := strings.Cut(line, ";")
+		if !ok {
+			return nil, fmt.Errorf("bad line: %q", line)
+		}
+		status, comment, ok := strings.Cut(rest, "#")
+		if !ok {
+			return nil, fmt.Errorf("bad line: %q", line)
+		}
+		status = strings.TrimSpace(status)
+		if _, ok := statuses[status]; !ok {
+			return nil, fmt.Errorf("unknown status %q in line: %q", status, line)
+		}
+		// comment = " 😀 E1.0 grinning face"
+		fields := strings.SplitN(strings.TrimSpace(comment), " ", 3)
+		if len(fields) != 3 || !strings.HasPrefix(fields[1], "E") {
+			return nil, fmt.Errorf("bad comment: %q", line)
+		}
+		major, minor, ok := strings.Cut(fields[1][1:], ".")
+		if !ok {
+			return nil, fmt.Errorf("bad version: %q", line)
+		}
+		t := test{
+			name:     fields[2],
+			group:    group,
+			subgroup: subgroup,
+			status:   statuses[status],
+		}
+		if t.major, err = strconv.Atoi(major); err != nil {
+			return nil, err
+		}
+		if t.minor, err = strconv.Atoi(minor); err != nil {
+			return nil, err
+		}
+		var runes []rune
+		for _, hex := range strings.Fields(cps) {
+			n, err := strconv.ParseInt(hex, 16, 64)
+			if err != nil {
+				return nil, err
+			}
+			runes = append(runes, rune(n))
+		}
+		tests[string(runes)] = t
+	}
+	return tests, scanner.Err()
+}
+
+// parseAnnotations reads the CLDR annotations for the given locale, keyed by emoji text without VS16.
+// Names come from "tts" annotations, and keywords from the others.
+// Both annotations/<locale>.xml and annotationsDerived/<locale>.xml (skin tones, flags, and so on) are required,
+// see gen.sh.
+func parseAnnotations(dir, locale string) (map[string]annotation, error) {
+	annotations := make(map[string]annotation)
+	for _, sub := range []string{"annotations", "annotationsDerived"} {
+		raw, err := os.ReadFile(filepath.Join(dir, sub, locale+".xml"))
+		if err != nil {
+			return nil, err
+		}
+		var doc struct {
+			Annotations []struct {
+				CP   string `xml:"cp,attr"`
+				Type string `xml:"type,attr"`
+				Text string `xml:",chardata"`
+			} `xml:"annotations>annotation"`
+		}
+		if err := xml.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("%s/%s.xml: %w", sub, locale, err)
+		}
+		for _, a := range doc.Annotations {
+			key := stripVS16(a.CP)
+			entry := annotations[key]
+			if a.Type == "tts" {
+				entry.name = strings.TrimSpace(a.Text)
+			} else {
+				for _, word := range strings.Split(a.Text, "|") {
+					if word = strings.TrimSpace(word); word != "" {
+						entry.keywords = append(entry.keywords, word)
+					}
+				}
+			}
+			annotations[key] = entry
+		}
+	}
+	return annotations, nil
+}
+
+type shortcode struct {
+	dialect string
+	code    string
+	str     string
+}
+
+var dialects = map[string]string{
+	"github": "GitHub",
+	"slack":  "Slack",
+}
+
+// parseShortcodes reads shortcode lists named after their dialect (github.txt, etc).
+// Each line is an emoji followed by its space-separated shortcodes.
+func parseShortcodes(dir string, emojis []emojiData) ([]shortcode, error) {
+	index := make(map[string]string, len(emojis))
+	for _, emoji := range emojis {
+		index[emoji.str] = emoji.str
+	}
+	for _, emoji := range emojis {
+		if _, ok := index[stripVS16(emoji.str)]; !ok {
+			index[stripVS16(emoji.str)] = emoji.str
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	var codes []shortcode
+	for _, path := range files {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		dialect, ok := dialects[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown shortcode dialect: %s", path)
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var list []shortcode
+		for _, line := range strings.Split(string(raw), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("%s: missing shortcode: %q", path, line)
+			}
+			str, ok := index[fields[0]]
+			if !ok {
+				str, ok = index[stripVS16(fields[0])]
+			}
+			if !ok {
+				// newer than the images
+				continue
+			}
+			for _, code := range fields[1:] {
+				list = append(list, shortcode{dialect: dialect, code: code, str: str})
+			}
+		}
+		slices.SortFunc(list, func(a, b shortcode) int {
+			return cmp.Compare(a.code, b.code)
+		})
+		codes = append(codes, list...)
+	}
+	return codes, nil
+}
+
+// cldrShortcodes derives shortcodes from CLDR short names,
+// e.g. "grinning face" → grinning_face.
+func cldrShortcodes(emojis []emojiData) []shortcode {
+	best := make(map[string]emojiData)
+	for _, emoji := range emojis {
+		if emoji.name == "" {
+			continue
+		}
+		code := shortcodeName(emoji.name)
+		if _, ok := best[code]; ok && emoji.status != "FullyQualified" {
+			continue
+		}
+		best[code] = emoji
+	}
+	codes := make([]shortcode, 0, len(best))
+	for code, emoji := range best {
+		codes = append(codes, shortcode{dialect: "CLDR", code: code, str: emoji.str})
+	}
+	slices.SortFunc(codes, func(a, b shortcode) int {
+		return cmp.Compare(a.code, b.code)
+	})
+	return codes
+}
+
+func shortcodeName(name string) string {
+	var b strings.Builder
+	underscore := false
+	name = strings.NewReplacer("#", " hash ", "*", " asterisk ").Replace(name)
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			underscore = false
+			b.WriteRune(r)
+			continue
+		}
+		underscore = true
+	}
+	return b.String()
+}
+
+func stripVS16(s string) string {
+	return strings.ReplaceAll(s, "\ufe0f", "")
 }
 
 func properly(a, b string) int {
@@ -78,21 +427,38 @@ func properly(a, b string) int {
 	return cmp.Compare(a, b)
 }
 
-// alias of actual unicode → twemoji file name
-// sequences with fe0f are missing in upstream?
-var aliases = map[string]string{
-	"23-fe0f-20e3.svg": "23-20e3.svg",
-	"2a-fe0f-20e3.svg": "2a-20e3.svg",
-	"30-fe0f-20e3.svg": "30-20e3.svg",
-	"31-fe0f-20e3.svg": "31-20e3.svg",
-	"32-fe0f-20e3.svg": "32-20e3.svg",
-	"33-fe0f-20e3.svg": "33-20e3.svg",
-	"34-fe0f-20e3.svg": "34-20e3.svg",
-	"35-fe0f-20e3.svg": "35-20e3.svg",
-	"36-fe0f-20e3.svg": "36-20e3.svg",
-	"37-fe0f-20e3.svg": "37-20e3.svg",
-	"38-fe0f-20e3.svg": "38-20e3.svg",
-	"39-fe0f-20e3.svg": "39-20e3.svg",
+// formAliases maps the file names of every form of an emoji listed in emoji-test.txt,
+// fully-qualified or not, to the file of its image. Twemoji only has one file per emoji,
+// named with or without U+FE0F.
+func formAliases(filenames []string, tests map[string]test) map[string]string {
+	images := make(map[string]string, len(filenames))
+	for _, name := range filenames {
+		images[stripVS16(parseName(name))] = name
+	}
+	have := make(map[string]bool, len(filenames))
+	for _, name := range filenames {
+		have[name] = true
+	}
+	aliases := make(map[string]string)
+	for text := range tests {
+		name := fileName(text)
+		if have[name] {
+			continue
+		}
+		if img, ok := images[stripVS16(text)]; ok {
+			aliases[name] = img
+		}
+	}
+	return aliases
+}
+
+// fileName returns the Twemoji file name of text, such as 23-fe0f-20e3.svg.
+func fileName(text string) string {
+	hexes := make([]string, 0, len(text))
+	for _, r := range text {
+		hexes = append(hexes, strconv.FormatInt(int64(r), 16))
+	}
+	return strings.Join(hexes, "-") + ".svg"
 }
 
 func parseName(base string) string {
@@ -3,5 +3,41 @@
 ver=$(cd twemoji; git describe --tags --abbrev=0)
 output=twemoji.go
 
-go run script/gen.go "${ver:1}" > $output
+# CLDR annotations for names and keywords, for English and the localized locales
+cldr=release-44
+locales=${EMOJIFY_LOCALES:-ja,de,pt}
+for locale in en ${locales//,/ }; do
+	for dir in annotations annotationsDerived; do
+		file=script/data/cldr/$dir/$locale.xml
+		if [ ! -f "$file" ]; then
+			mkdir -p "$(dirname "$file")"
+			curl -fsSL -o "$file" "https://raw.githubusercontent.com/unicode-org/cldr/$cldr/common/$dir/$locale.xml" || {
+				echo "couldn't fetch $file" >&2
+				exit 1
+			}
+		fi
+	done
+done
+
+# shortcode lists: GitHub's from gemoji, and Slack's from emoji-datasource
+gemoji=master
+datasource=v15.1.2
+upstream=$(mktemp -d)
+trap 'rm -rf "$upstream"' EXIT
+curl -fsSL -o "$upstream/gemoji.json" "https://raw.githubusercontent.com/github/gemoji/$gemoji/db/emoji.json" || exit 1
+curl -fsSL -o "$upstream/datasource.json" "https://raw.githubusercontent.com/iamcal/emoji-data/$datasource/emoji.json" || exit 1
+go run ./script/shortcodes -from gemoji -dialect GitHub < "$upstream/gemoji.json" > script/data/shortcodes/github.txt || exit 1
+go run ./script/shortcodes -from emoji-datasource -dialect Slack < "$upstream/datasource.json" > script/data/shortcodes/slack.txt || exit 1
+
+go run script/gen.go -locales "$locales" "${ver:1}" > $output
 gofmt -w $output
+
+# local copy of the images, embedded with -tags emojify_embed
+find assets -type f ! -name .gitkeep -delete
+cp -r twemoji/assets/svg twemoji/assets/72x72 assets/
+
+# pre-compressed variants for AssetHandler
+find assets/svg -name '*.svg' -exec gzip -k -9 {} +
+if command -v brotli > /dev/null; then
+	find assets/svg -name '*.svg' -exec brotli -k -q 11 {} +
+fi
@@ -0,0 +1,109 @@
+// Command shortcodes converts upstream emoji data into the shortcode lists read by gen.go.
+// It reads JSON from stdin and writes the list to stdout, see gen.sh.
+//
+//	go run ./script/shortcodes -from gemoji -dialect GitHub < emoji.json > script/data/shortcodes/github.txt
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	from    = flag.String("from", "", "format of the input: gemoji (db/emoji.json) or emoji-datasource (emoji.json)")
+	dialect = flag.String("dialect", "", "name of the dialect, for the header")
+)
+
+// entry is an emoji and its shortcodes.
+type entry struct {
+	emoji string
+	codes []string
+}
+
+func main() {
+	flag.Parse()
+	var entries []entry
+	var err error
+	switch *from {
+	case "gemoji":
+		entries, err = parseGemoji()
+	case "emoji-datasource":
+		entries, err = parseDatasource()
+	default:
+		err = fmt.Errorf("unknown format: %q", *from)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("# %s shortcodes, generated from %s by gen.sh. DO NOT EDIT.\n", *dialect, *from)
+	fmt.Println("# Format: emoji followed by its shortcodes, separated by spaces.")
+	for _, e := range entries {
+		if len(e.codes) == 0 {
+			continue
+		}
+		fmt.Println(e.emoji, strings.Join(e.codes, " "))
+	}
+}
+
+// parseGemoji reads GitHub's gemoji database.
+func parseGemoji() ([]entry, error) {
+	var list []struct {
+		Emoji   string   `json:"emoji"`
+		Aliases []string `json:"aliases"`
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&list); err != nil {
+		return nil, fmt.Errorf("gemoji: %w", err)
+	}
+	entries := make([]entry, 0, len(list))
+	for _, item := range list {
+		entries = append(entries, entry{emoji: item.Emoji, codes: item.Aliases})
+	}
+	return entries, nil
+}
+
+// parseDatasource reads iamcal's emoji-datasource, whose short names are used by Slack.
+func parseDatasource() ([]entry, error) {
+	type item struct {
+		Unified    string   `json:"unified"`
+		ShortNames []string `json:"short_names"`
+		SortOrder  int      `json:"sort_order"`
+	}
+	var list []item
+	if err := json.NewDecoder(os.Stdin).Decode(&list); err != nil {
+		return nil, fmt.Errorf("emoji-datasource: %w", err)
+	}
+	// in emoji-test.txt order, like gemoji
+	slices.SortStableFunc(list, func(a, b item) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+	entries := make([]entry, 0, len(list))
+	for _, item := range list {
+		emoji, err := unhex(item.Unified)
+		if err != nil {
+			return nil, fmt.Errorf("emoji-datasource: %w", err)
+		}
+		entries = append(entries, entry{emoji: emoji, codes: item.ShortNames})
+	}
+	return entries, nil
+}
+
+// unhex parses dash-separated code points, such as 1F44D-1F3FB.
+func unhex(s string) (string, error) {
+	var b strings.Builder
+	for _, hex := range strings.Split(s, "-") {
+		r, err := strconv.ParseInt(hex, 16, 32)
+		if err != nil {
+			return "", err
+		}
+		b.WriteRune(rune(r))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,418 @@
+package emojify
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+// SearchIndex is an in-memory index of emoji names, keywords, and shortcodes, for emoji pickers and autocomplete.
+// It only contains emoji that its [Twemoji] can render, including custom emoji.
+// Skin tone variants are folded into their base emoji, see [InSkinTone].
+// It is safe for concurrent use.
+type SearchIndex struct {
+	entries []searchEntry
+}
+
+type searchEntry struct {
+	emoji      Emoji
+	shortcodes []string
+	terms      []searchTerm
+	order      int
+}
+
+type searchTerm struct {
+	raw   string
+	text  string // lowercase words separated by spaces
+	kind  termKind
+	words []string
+	lang  int // index of the locale, or -1 for English
+}
+
+type termKind uint8
+
+const (
+	shortcodeTerm termKind = iota
+	nameTerm
+	keywordTerm
+)
+
+// SearchResult is an emoji found by [SearchIndex.Search].
+type SearchResult struct {
+	Emoji
+	// Shortcode is the best matching shortcode of the emoji, or its first, without colons.
+	// Empty if the emoji has no shortcodes.
+	Shortcode string
+	// Score is the relevance of the result. Higher is better.
+	Score float64
+}
+
+// SearchOption configures [Twemoji.Search] and [SearchIndex.Search].
+type SearchOption func(*searchConfig)
+
+type searchConfig struct {
+	lang       language.Tag
+	limit      int
+	groups     []string
+	maxVersion *EmojiVersion
+	popularity func(Emoji) float64
+	tone       SkinTone
+}
+
+// InLanguage searches names and keywords in the language best matching tag, in addition to English.
+// See [Languages] for the available languages.
+func InLanguage(tag language.Tag) SearchOption {
+	return func(sc *searchConfig) {
+		sc.lang = tag
+	}
+}
+
+// Limit returns at most n results.
+func Limit(n int) SearchOption {
+	return func(sc *searchConfig) {
+		sc.limit = n
+	}
+}
+
+// InGroups only returns emoji in the given groups, such as "Smileys & Emotion". See [Emoji.Group].
+func InGroups(groups ...string) SearchOption {
+	return func(sc *searchConfig) {
+		sc.groups = groups
+	}
+}
+
+// MaxVersion only returns emoji introduced in Emoji version v or earlier, for clients that can't display newer emoji.
+// Custom and non-standard emoji are always included.
+func MaxVersion(v EmojiVersion) SearchOption {
+	return func(sc *searchConfig) {
+		sc.maxVersion = &v
+	}
+}
+
+// ByPopularity ranks results by popularity as well as relevance.
+// The function returns the popularity of an emoji between 0 and 1, such as how often a user has picked it.
+func ByPopularity(fn func(Emoji) float64) SearchOption {
+	return func(sc *searchConfig) {
+		sc.popularity = fn
+	}
+}
+
+// InSkinTone returns the variants of results in the given skin tone, where available.
+func InSkinTone(tone SkinTone) SearchOption {
+	return func(sc *searchConfig) {
+		sc.tone = tone
+	}
+}
+
+// Search returns the emoji matching query, most relevant first.
+// See [Twemoji.SearchIndex] for details.
+func (tw Twemoji) Search(query string, opts ...SearchOption) []Emoji {
+	results := tw.SearchIndex().Search(query, opts...)
+	if len(results) == 0 {
+		return nil
+	}
+	found := make([]Emoji, len(results))
+	for i, result := range results {
+		found[i] = result.Emoji
+	}
+	return found
+}
+
+// SearchIndex returns the search index of tw's emoji, building it on first use.
+func (tw Twemoji) SearchIndex() *SearchIndex {
+	if tw.replacer == nil {
+		return defaults().SearchIndex()
+	}
+	return tw.search()
+}
+
+// searchOnce holds a lazily built [SearchIndex].
+type searchOnce struct {
+	once  sync.Once
+	index *SearchIndex
+}
+
+func (tw Twemoji) search() *SearchIndex {
+	tw.searchIndex.once.Do(func() {
+		tw.searchIndex.index = tw.newSearchIndex()
+	})
+	return tw.searchIndex.index
+}
+
+func (tw Twemoji) newSearchIndex() *SearchIndex {
+	// shortcodes of the configured dialects, or every dialect to help find emoji
+	dialects := tw.dialects
+	if len(dialects) == 0 {
+		dialects = []Dialect{GitHub, Slack, CLDR}
+	}
+	shortcodes := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, dialect := range dialects {
+		for _, sc := range shortcodeData {
+			if sc.dialect == dialect && !seen[sc.code] {
+				seen[sc.code] = true
+				key := toneKey(sc.str)
+				shortcodes[key] = append(shortcodes[key], sc.code)
+			}
+		}
+	}
+
+	idx := &SearchIndex{}
+	ls := locales()
+	added := make(map[string]bool)
+	for _, c := range tw.custom {
+		token := c.token()
+		if c.Name == "" || added[token] {
+			continue
+		}
+		added[token] = true
+		entry := searchEntry{
+			emoji: Emoji{Text: token, Name: c.Name, Status: NonStandard},
+			order: len(idx.entries),
+		}
+		if c.Token == "" {
+			entry.shortcodes = []string{c.Name}
+			entry.terms = append(entry.terms, newSearchTerm(c.Name, shortcodeTerm, -1))
+		} else {
+			entry.terms = append(entry.terms, newSearchTerm(c.Name, nameTerm, -1))
+		}
+		idx.entries = append(idx.entries, entry)
+	}
+	for _, item := range tw.builtin {
+		if item.text != "" || item.name == "" || hasSkinTone(item.str) {
+			// skip shortcodes, unnamed emoji, and skin tone variants
+			continue
+		}
+		key := toneKey(item.str)
+		if added[key] {
+			continue
+		}
+		added[key] = true
+		e := item.emoji()
+		entry := searchEntry{
+			emoji:      e,
+			shortcodes: shortcodes[key],
+			order:      len(idx.entries),
+		}
+		for _, code := range entry.shortcodes {
+			entry.terms = append(entry.terms, newSearchTerm(code, shortcodeTerm, -1))
+		}
+		entry.terms = append(entry.terms, newSearchTerm(e.Name, nameTerm, -1))
+		for _, word := range e.Keywords {
+			entry.terms = append(entry.terms, newSearchTerm(word, keywordTerm, -1))
+		}
+		for i, loc := range ls.locales {
+			a := loc.annotations[key]
+			if a.name != "" {
+				entry.terms = append(entry.terms, newSearchTerm(a.name, nameTerm, i))
+			}
+			for _, word := range a.keywords {
+				entry.terms = append(entry.terms, newSearchTerm(word, keywordTerm, i))
+			}
+		}
+		idx.entries = append(idx.entries, entry)
+	}
+	return idx
+}
+
+func newSearchTerm(text string, kind termKind, lang int) searchTerm {
+	words := splitWords(strings.ToLower(text))
+	return searchTerm{
+		raw:   text,
+		text:  strings.Join(words, " "),
+		kind:  kind,
+		words: words,
+		lang:  lang,
+	}
+}
+
+// splitWords splits s on spaces and punctuation such as the underscores of shortcodes.
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || r == '-' || r == ':' || r == ','
+	})
+}
+
+// Search returns the emoji matching query, most relevant first.
+// Names, keywords, and shortcodes are matched exactly, by prefix (such as :sm for :smile:),
+// by the prefix of any of their words, by substring, and fuzzily by abbreviation (such as "thmbs" for "thumbs").
+// Every word of query must match.
+func (idx *SearchIndex) Search(query string, opts ...SearchOption) []SearchResult {
+	var sc searchConfig
+	for _, opt := range opts {
+		opt(&sc)
+	}
+	shortcode := strings.HasPrefix(strings.TrimSpace(query), ":")
+	words := splitWords(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+	query = strings.Join(words, " ")
+	lang := -1
+	if i, ok := locales().match(sc.lang); ok {
+		lang = i
+	}
+
+	var results []SearchResult
+	var order []int
+	for _, entry := range idx.entries {
+		if !sc.allows(entry.emoji) {
+			continue
+		}
+		score, code := entry.score(query, words, lang, shortcode)
+		if score == 0 {
+			continue
+		}
+		e := entry.emoji
+		if sc.popularity != nil {
+			score *= 1 + min(max(sc.popularity(e), 0), 1)
+		}
+		if sc.tone != NoSkinTone {
+			e = e.withTone(sc.tone)
+		}
+		results = append(results, SearchResult{Emoji: e, Shortcode: code, Score: score})
+		order = append(order, entry.order)
+	}
+
+	ranked := make([]int, len(results))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	slices.SortStableFunc(ranked, func(a, b int) int {
+		if n := cmp.Compare(results[b].Score, results[a].Score); n != 0 {
+			return n
+		}
+		if n := cmp.Compare(len(results[a].Name), len(results[b].Name)); n != 0 {
+			return n
+		}
+		return cmp.Compare(order[a], order[b])
+	})
+	sorted := make([]SearchResult, 0, len(results))
+	for _, i := range ranked {
+		sorted = append(sorted, results[i])
+	}
+	if sc.limit > 0 && len(sorted) > sc.limit {
+		sorted = sorted[:sc.limit]
+	}
+	return sorted
+}
+
+func (sc *searchConfig) allows(e Emoji) bool {
+	if len(sc.groups) > 0 && !slices.ContainsFunc(sc.groups, func(group string) bool {
+		return strings.EqualFold(group, e.Group)
+	}) {
+		return false
+	}
+	if sc.maxVersion != nil && e.Status != NonStandard && e.Version.Compare(*sc.maxVersion) > 0 {
+		return false
+	}
+	return true
+}
+
+// score returns the relevance of entry for the query, and the best matching shortcode.
+// Every word of the query must match a term.
+func (entry searchEntry) score(query string, words []string, lang int, shortcode bool) (float64, string) {
+	var total float64
+	var code string
+	var codeScore float64
+	for _, word := range words {
+		var best float64
+		for _, term := range entry.terms {
+			if term.lang != -1 && term.lang != lang {
+				continue
+			}
+			s := matchScore(word, term)
+			if s == 0 {
+				continue
+			}
+			switch term.kind {
+			case shortcodeTerm:
+				if shortcode {
+					s *= 1.5
+				}
+				if s > codeScore {
+					code, codeScore = term.raw, s
+				}
+			case keywordTerm:
+				s *= 0.7
+			}
+			best = max(best, s)
+		}
+		if best == 0 {
+			return 0, ""
+		}
+		total += best
+	}
+	// a phrase matching a whole term, such as thumbs_u, is better than scattered words
+	if len(words) > 1 {
+		for _, term := range entry.terms {
+			if (term.lang == -1 || term.lang == lang) && strings.HasPrefix(term.text, query) {
+				total += 50
+				if term.kind == shortcodeTerm && codeScore < 1000 {
+					code, codeScore = term.raw, 1000
+				}
+				break
+			}
+		}
+	}
+	if code == "" && len(entry.shortcodes) > 0 {
+		code = entry.shortcodes[0]
+	}
+	return total, code
+}
+
+// matchScore returns how well q matches term, or 0 if it doesn't.
+func matchScore(q string, term searchTerm) float64 {
+	switch {
+	case term.text == q:
+		return 100
+	case strings.HasPrefix(term.text, q):
+		// prefer shorter completions
+		return 80 - float64(min(utf8.RuneCountInString(term.text)-utf8.RuneCountInString(q), 20))
+	}
+	for _, word := range term.words {
+		if word == q {
+			return 70
+		}
+		if strings.HasPrefix(word, q) {
+			return 60
+		}
+	}
+	if utf8.RuneCountInString(q) > 1 && strings.Contains(term.text, q) {
+		return 40
+	}
+	for _, word := range term.words {
+		if fuzzy(q, word) {
+			return 20
+		}
+	}
+	return 0
+}
+
+// fuzzy reports whether q is an abbreviation of word: it shares the first letter,
+// and its letters appear in order without straying too far apart.
+func fuzzy(q, word string) bool {
+	if utf8.RuneCountInString(q) < 3 || len(word) < len(q) {
+		return false
+	}
+	qr, wr := []rune(q), []rune(word)
+	if qr[0] != wr[0] {
+		return false
+	}
+	i, last := 1, 0
+	for j := 1; j < len(wr) && i < len(qr); j++ {
+		if wr[j] == qr[i] {
+			if j-last > 3 {
+				return false
+			}
+			i++
+			last = j
+		}
+	}
+	return i == len(qr)
+}
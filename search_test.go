@@ -0,0 +1,104 @@
+package emojify
+
+import (
+	"testing"
+)
+
+func TestSearchIndex(t *testing.T) {
+	tw := New(WithShortcodes(Slack))
+	idx := tw.SearchIndex()
+	if tw.SearchIndex() != idx {
+		t.Error("SearchIndex not reused")
+	}
+
+	tests := []struct {
+		query     string
+		opts      []SearchOption
+		emoji     string
+		shortcode string
+	}{
+		{query: ":smile", emoji: "😄", shortcode: "smile"},
+		{query: ":thumbs", emoji: "👍", shortcode: "thumbsup"},
+		{query: "thumbs_u", emoji: "👍", shortcode: "thumbsup"},
+		{query: "+1", emoji: "👍", shortcode: "+1"},
+		{query: "red heart", emoji: "❤️", shortcode: "heart"},
+		{query: "thmbs", emoji: "👍"},
+		{query: "flag jap", emoji: "🇯🇵"},
+		{query: "cat face", emoji: "🐱"},
+		{query: "waving", opts: []SearchOption{InSkinTone(DarkSkinTone)}, emoji: "👋🏿"},
+	}
+	for _, test := range tests {
+		results := idx.Search(test.query, test.opts...)
+		if len(results) == 0 {
+			t.Errorf("Search(%q): no results", test.query)
+			continue
+		}
+		if got := results[0]; got.Text != test.emoji || (test.shortcode != "" && got.Shortcode != test.shortcode) {
+			t.Errorf("Search(%q)[0] = %q (%s), want %q (%s)", test.query, got.Text, got.Shortcode, test.emoji, test.shortcode)
+		}
+		for i := 1; i < len(results); i++ {
+			if results[i].Score > results[i-1].Score {
+				t.Errorf("Search(%q): results out of order at %d", test.query, i)
+				break
+			}
+		}
+	}
+}
+
+func TestSearchOptions(t *testing.T) {
+	idx := New().SearchIndex()
+
+	if results := idx.Search("face", Limit(3)); len(results) != 3 {
+		t.Errorf("Limit(3): got %d results", len(results))
+	}
+	for _, r := range idx.Search("cat", InGroups("smileys & emotion")) {
+		if r.Group != "Smileys & Emotion" {
+			t.Errorf("InGroups: got %q in %q", r.Text, r.Group)
+		}
+	}
+	for _, r := range idx.Search("face", MaxVersion(EmojiVersion{Major: 1})) {
+		if r.Version.Compare(EmojiVersion{Major: 1}) > 0 {
+			t.Errorf("MaxVersion: got %q from %v", r.Text, r.Version)
+		}
+	}
+
+	// skin tone variants are folded into their base emoji
+	for _, r := range idx.Search("waving hand") {
+		if hasSkinTone(r.Text) {
+			t.Errorf("got skin tone variant %q", r.Text)
+		}
+	}
+
+	first := idx.Search("heart")[0]
+	popular := idx.Search("heart", ByPopularity(func(e Emoji) float64 {
+		if e.Text == first.Text {
+			return 0
+		}
+		if e.Name == "blue heart" {
+			return 1
+		}
+		return 0
+	}))
+	if popular[0].Name != "blue heart" {
+		t.Errorf("ByPopularity: got %q first, want blue heart", popular[0].Name)
+	}
+}
+
+func TestFuzzy(t *testing.T) {
+	tests := []struct {
+		q, word string
+		want    bool
+	}{
+		{"thmbs", "thumbs", true},
+		{"hrt", "heart", true},
+		{"hrt", "earth", false},
+		{"th", "thumbs", false},
+		{"tmbs", "thumbs", true},
+		{"tzs", "thumbs", false},
+	}
+	for _, test := range tests {
+		if got := fuzzy(test.q, test.word); got != test.want {
+			t.Errorf("fuzzy(%q, %q) = %v, want %v", test.q, test.word, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package emojify
+
+// Dialect is a flavor of shortcodes, such as GitHub's :+1:.
+type Dialect string
+
+const (
+	// GitHub shortcodes (gemoji).
+	GitHub Dialect = "github"
+	// Slack shortcodes.
+	Slack Dialect = "slack"
+	// CLDR shortcodes are derived from CLDR short names, such as :grinning_face:.
+	CLDR Dialect = "cldr"
+)
+
+type shortcode struct {
+	dialect Dialect
+	code    string // without colons
+	str     string // unicode text
+}
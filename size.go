@@ -0,0 +1,136 @@
+package emojify
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// Size is the displayed size of emoji images.
+// The zero value omits dimensions entirely, leaving them to CSS.
+type Size struct {
+	// Width and Height in pixels, given as width and height attributes. Zero omits the attribute.
+	Width, Height int
+	// Em is the width and height in ems, given as an inline style. It takes precedence over Width and Height.
+	Em float64
+}
+
+// defaultSize matches the 72x72 PNG images.
+var defaultSize = Size{Width: 72, Height: 72}
+
+func (s Size) attrs() []html.Attribute {
+	if s.Em != 0 {
+		em := strconv.FormatFloat(s.Em, 'f', -1, 64) + "em"
+		return []html.Attribute{{Key: "style", Val: "width:" + em + ";height:" + em}}
+	}
+	var attrs []html.Attribute
+	if s.Width != 0 {
+		attrs = append(attrs, html.Attribute{Key: "width", Val: strconv.Itoa(s.Width)})
+	}
+	if s.Height != 0 {
+		attrs = append(attrs, html.Attribute{Key: "height", Val: strconv.Itoa(s.Height)})
+	}
+	return attrs
+}
+
+func (s Size) valid() bool {
+	return s.Width >= 0 && s.Height >= 0 && s.Em >= 0
+}
+
+// WithSize specifies the displayed size of emoji images. Default is 72x72 px.
+// Use Size{} to omit dimensions, or Size{Em: 1} to size images relative to the text around them.
+func WithSize(size Size) Option {
+	return func(t *Twemoji) {
+		t.size = size
+	}
+}
+
+// WithJumboSize specifies a size for emoji in text that consists only of emoji, see [Twemoji.OnlyEmoji].
+// It's used by [Twemoji.Replace], [Twemoji.HTML], [Twemoji.WriteString], and [Twemoji.ReplaceHTML],
+// but not by the streaming methods, which can't see the text in full.
+// The jumbo images are prepared the first time they're needed.
+func WithJumboSize(size Size) Option {
+	return func(t *Twemoji) {
+		t.jumboSize = &size
+	}
+}
+
+// jumboOnce holds the lazily loaded configuration used for text consisting only of emoji.
+type jumboOnce struct {
+	once  sync.Once
+	jumbo *Twemoji
+}
+
+// loadJumbo returns the configuration used for text consisting only of emoji, loading it on first use.
+// tw must have a jumbo size.
+func (tw Twemoji) loadJumbo() *Twemoji {
+	tw.jumbo.once.Do(func() {
+		jumbo := tw
+		jumbo.jumbo = nil
+		jumbo.size = *tw.jumboSize
+		if err := jumbo.load(); err != nil {
+			// the regular size loaded fine, so fall back to it
+			jumbo = tw
+			jumbo.jumbo = nil
+		}
+		tw.jumbo.jumbo = &jumbo
+	})
+	return tw.jumbo.jumbo
+}
+
+// OnlyEmoji reports whether s consists of at least one emoji and nothing else but whitespace.
+// Shortcodes and custom emoji count as emoji.
+func (tw Twemoji) OnlyEmoji(s string) bool {
+	if tw.replacer == nil {
+		return defaults().OnlyEmoji(s)
+	}
+	var found bool
+	for len(s) > 0 {
+		idx, m, _ := tw.next(s, true)
+		if strings.TrimFunc(s[:idx], ignorable) != "" {
+			return false
+		}
+		if m == nil {
+			break
+		}
+		if m.plain {
+			return false
+		}
+		found = true
+		s = s[idx+len(m.str):]
+	}
+	return found
+}
+
+// ignorable reports whether r can be ignored between emoji.
+func ignorable(r rune) bool {
+	return unicode.IsSpace(r) || r == zwj || r == '\ufe0e' || r == '\u200d'
+}
+
+// onlyEmojiHTML reports whether the text of root consists only of emoji.
+// Text in skipped elements, such as <code>, isn't replaced, so it never counts as emoji.
+func (tw Twemoji) onlyEmojiHTML(root *html.Node) bool {
+	var text strings.Builder
+	var skipped bool // found text that won't be replaced
+	var walk func(node *html.Node, skip bool)
+	walk = func(node *html.Node, skip bool) {
+		switch node.Type {
+		case html.TextNode:
+			if skip {
+				skipped = skipped || strings.TrimFunc(node.Data, ignorable) != ""
+				return
+			}
+			text.WriteString(node.Data)
+		case html.ElementNode:
+			skip = skip || tw.skips(node.Data, node.Attr)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, skip)
+		}
+	}
+	walk(root, false)
+	return !skipped && tw.OnlyEmoji(text.String())
+}
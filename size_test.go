@@ -0,0 +1,96 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestWithSize(t *testing.T) {
+	tests := []struct {
+		size Size
+		want string
+	}{
+		{Size{Width: 20, Height: 20}, `<img draggable="false" class="emoji" src="/t/svg/1f30e.svg" width="20" height="20" alt="🌎"/>`},
+		{Size{}, `<img draggable="false" class="emoji" src="/t/svg/1f30e.svg" alt="🌎"/>`},
+		{Size{Em: 1.25}, `<img draggable="false" class="emoji" src="/t/svg/1f30e.svg" style="width:1.25em;height:1.25em" alt="🌎"/>`},
+	}
+	for _, test := range tests {
+		tw := New(WithCDN("/t/"), WithSize(test.size))
+		if got := tw.Replace("🌎"); got != test.want {
+			t.Errorf("%+v →\n got: %q\nwant: %q", test.size, got, test.want)
+		}
+	}
+
+	if _, err := NewTwemoji(WithSize(Size{Width: -1})); err == nil {
+		t.Error("expected error for negative size")
+	}
+}
+
+func TestOnlyEmoji(t *testing.T) {
+	tw := New(WithShortcodes(GitHub), WithCustomEmoji(CustomEmoji{Name: "parrot", URL: "/parrot.gif"}))
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"🌎", true},
+		{" 🌎 🐦\n", true},
+		{"👩‍❤️‍👨", true},
+		{"❤️", true},
+		{":+1: :parrot:", true},
+		{"", false},
+		{"   ", false},
+		{"hi 🌎", false},
+		{"🌎!", false},
+	}
+	for _, test := range tests {
+		if got := tw.OnlyEmoji(test.in); got != test.want {
+			t.Errorf("OnlyEmoji(%q) = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestWithJumboSize(t *testing.T) {
+	tw := New(WithCDN("/t/"), WithSize(Size{Em: 1}), WithJumboSize(Size{Em: 2}))
+	if got := tw.Replace("🌎 🌎"); strings.Count(got, "width:2em") != 2 {
+		t.Errorf("expected jumbo emoji: %q", got)
+	}
+	if got := string(tw.HTML("hi 🌎")); !strings.Contains(got, "width:1em") || strings.Contains(got, "width:2em") {
+		t.Errorf("expected regular emoji: %q", got)
+	}
+
+	doc, err := html.Parse(strings.NewReader("<p>🌎</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.ReplaceHTML(doc)
+	var buf strings.Builder
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "width:2em") {
+		t.Errorf("expected jumbo emoji in HTML: %s", buf.String())
+	}
+	// text that isn't replaced doesn't count as emoji
+	doc, err = html.Parse(strings.NewReader("<p>🌎 <code>🌎</code></p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.ReplaceHTML(doc)
+	buf.Reset()
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "width:1em") {
+		t.Errorf("expected regular emoji in HTML: %s", buf.String())
+	}
+
+	reloaded, err := tw.ReloadCustom(CustomEmoji{Name: "parrot", URL: "/parrot.gif"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Replace(":parrot:"); !strings.Contains(got, "width:2em") {
+		t.Errorf("expected jumbo custom emoji: %q", got)
+	}
+}
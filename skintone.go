@@ -0,0 +1,266 @@
+package emojify
+
+import (
+	"cmp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// SkinTone is an emoji modifier for skin tone, U+1F3FB through U+1F3FF.
+// See: https://www.unicode.org/reports/tr51/#Emoji_Modifiers
+type SkinTone rune
+
+const (
+	// NoSkinTone is the default (yellow) skin tone.
+	NoSkinTone          SkinTone = 0
+	LightSkinTone       SkinTone = '\U0001F3FB'
+	MediumLightSkinTone SkinTone = '\U0001F3FC'
+	MediumSkinTone      SkinTone = '\U0001F3FD'
+	MediumDarkSkinTone  SkinTone = '\U0001F3FE'
+	DarkSkinTone        SkinTone = '\U0001F3FF'
+)
+
+// skinTones are the modifiers, in order.
+var skinTones = [...]SkinTone{LightSkinTone, MediumLightSkinTone, MediumSkinTone, MediumDarkSkinTone, DarkSkinTone}
+
+func (t SkinTone) String() string {
+	switch t {
+	case NoSkinTone:
+		return "none"
+	case LightSkinTone:
+		return "light skin tone"
+	case MediumLightSkinTone:
+		return "medium-light skin tone"
+	case MediumSkinTone:
+		return "medium skin tone"
+	case MediumDarkSkinTone:
+		return "medium-dark skin tone"
+	case DarkSkinTone:
+		return "dark skin tone"
+	}
+	return "SkinTone(" + string(rune(t)) + ")"
+}
+
+func isSkinTone(r rune) bool {
+	return r >= rune(LightSkinTone) && r <= rune(DarkSkinTone)
+}
+
+// toneKey returns emoji without skin tones or emoji presentation selectors,
+// which is shared by an emoji and its skin tone variants.
+func toneKey(emoji string) string {
+	return strings.Map(func(r rune) rune {
+		if isSkinTone(r) || r == zwj {
+			return -1
+		}
+		return r
+	}, emoji)
+}
+
+// hasSkinTone reports whether emoji contains a skin tone modifier, not counting a lone modifier.
+func hasSkinTone(emoji string) bool {
+	return strings.IndexFunc(emoji, isSkinTone) > 0
+}
+
+// toneSet is an emoji and its skin tone variants.
+type toneSet struct {
+	base string // without skin tones
+	// people is 1, or 2 for sequences such as 🧑‍🤝‍🧑 with a skin tone per person
+	people   int
+	variants map[[2]SkinTone]string // by the tone of each person, the same twice for one person
+}
+
+// toneSets indexes the emoji with skin tone variants by [toneKey], of the base emoji and of each variant.
+// Some variants aren't the base emoji plus modifiers: 👫 with two tones is 👩🏻‍🤝‍👨🏿,
+// so those are matched to their base emoji by CLDR name instead.
+var toneSets = sync.OnceValue(func() map[string]*toneSet {
+	bases := make(map[string]string)
+	byName := make(map[string]string)
+	for _, item := range twemojiData {
+		if hasSkinTone(item.str) {
+			continue
+		}
+		key := toneKey(item.str)
+		if _, ok := bases[key]; !ok || item.status == FullyQualified {
+			base := cmp.Or(item.qualified, item.str)
+			bases[key] = base
+			byName[item.name] = base
+		}
+	}
+
+	sets := make(map[string]*toneSet)
+	for _, item := range twemojiData {
+		if !hasSkinTone(item.str) {
+			continue
+		}
+		var tones []SkinTone
+		for _, r := range item.str {
+			if isSkinTone(r) {
+				tones = append(tones, SkinTone(r))
+			}
+		}
+		if len(tones) > 2 {
+			continue
+		}
+		key := toneKey(item.str)
+		base, ok := bases[key]
+		if !ok {
+			name, _, _ := strings.Cut(item.name, ":")
+			if base, ok = byName[name]; !ok {
+				continue
+			}
+		}
+		set := sets[toneKey(base)]
+		if set == nil {
+			set = &toneSet{base: base, people: 1, variants: make(map[[2]SkinTone]string)}
+			sets[toneKey(base)] = set
+		}
+		set.people = max(set.people, len(tones))
+		pair := [2]SkinTone{tones[0], tones[len(tones)-1]}
+		if v := set.variants[pair]; v == "" || item.status == FullyQualified {
+			set.variants[pair] = item.str
+		}
+		sets[key] = set
+	}
+	return sets
+})
+
+// variant returns the variant of the set with the given tones, one per person.
+// A single tone applies to everyone.
+func (set *toneSet) variant(tones ...SkinTone) (string, bool) {
+	if len(tones) == 0 || len(tones) > set.people {
+		return "", false
+	}
+	pair := [2]SkinTone{tones[0], tones[len(tones)-1]}
+	if pair == [2]SkinTone{} {
+		return set.base, true
+	}
+	v, ok := set.variants[pair]
+	return v, ok
+}
+
+// withTone returns the variant of e in the given skin tone, or e if it has none.
+func (e Emoji) withTone(tone SkinTone) Emoji {
+	set := toneSets()[toneKey(e.Text)]
+	if set == nil {
+		return e
+	}
+	v, ok := set.variant(tone)
+	if !ok {
+		return e
+	}
+	if toned, ok := lookup(v); ok {
+		return toned
+	}
+	return e
+}
+
+// SkinTones returns how many skin tones emoji takes: 0 if it has no skin tone variants,
+// 1 for most, or 2 for sequences of two people such as 🧑‍🤝‍🧑, which can have a skin tone each.
+// Toned variants count the same as their base emoji. Only variants with images are considered.
+func (tw Twemoji) SkinTones(emoji string) int {
+	if tw.replacer == nil {
+		return defaults().SkinTones(emoji)
+	}
+	set := toneSets()[toneKey(emoji)]
+	if set == nil {
+		return 0
+	}
+	people := 0
+	for pair, v := range set.variants {
+		if !tw.renders(v) {
+			continue
+		}
+		if pair[0] != pair[1] {
+			return 2
+		}
+		people = 1
+	}
+	return people
+}
+
+// SupportsSkinTone reports whether emoji has skin tone variants, see [Twemoji.SkinTones].
+func (tw Twemoji) SupportsSkinTone(emoji string) bool {
+	return tw.SkinTones(emoji) > 0
+}
+
+// WithSkinTone returns the variant of emoji with the given skin tones, such as 👍🏽 for 👍 and [MediumSkinTone].
+// A single tone applies to every person in the emoji. Emoji of two people, such as 🧑‍🤝‍🧑, take a tone for each.
+// Emoji that already have skin tones are changed to the given tones, and [NoSkinTone] returns the base emoji.
+// It returns emoji as-is if there is no such variant, or tw has no image for it.
+func (tw Twemoji) WithSkinTone(emoji string, tones ...SkinTone) string {
+	if tw.replacer == nil {
+		return defaults().WithSkinTone(emoji, tones...)
+	}
+	set := toneSets()[toneKey(emoji)]
+	if set == nil {
+		return emoji
+	}
+	v, ok := set.variant(tones...)
+	if !ok || !tw.renders(v) {
+		return emoji
+	}
+	return v
+}
+
+// StripSkinTone returns emoji without skin tones, such as 👍 for 👍🏽.
+// Other text has its skin tone modifiers removed.
+func (tw Twemoji) StripSkinTone(emoji string) string {
+	if set := toneSets()[toneKey(emoji)]; set != nil {
+		return set.base
+	}
+	return strings.Map(func(r rune) rune {
+		if isSkinTone(r) {
+			return -1
+		}
+		return r
+	}, emoji)
+}
+
+// WithDefaultSkinTone renders emoji without a skin tone in the given tone, such as 👍 as 👍🏽.
+// Only the image is of the toned variant: the alt text and labels are of the emoji as written,
+// so [Twemoji.Unreplace] gives back the original text.
+// Emoji of two people use the same tone for both.
+func WithDefaultSkinTone(tone SkinTone) Option {
+	return func(t *Twemoji) {
+		t.skinTone = tone
+	}
+}
+
+// renders reports whether tw has an image for emoji.
+func (tw Twemoji) renders(emoji string) bool {
+	head, _ := utf8.DecodeRuneInString(emoji)
+	for _, item := range tw.nodes[head] {
+		if item.str == emoji && item.text == "" && !item.plain {
+			return true
+		}
+	}
+	return false
+}
+
+// tonedSequences returns the variants of seqs in the default skin tone, by the text of their base emoji.
+func (tw Twemoji) tonedSequences(seqs []resource) map[string]resource {
+	if tw.skinTone == NoSkinTone {
+		return nil
+	}
+	byStr := make(map[string]int, len(seqs))
+	for i, item := range seqs {
+		byStr[item.str] = i
+	}
+	sets := toneSets()
+	toned := make(map[string]resource)
+	for _, item := range seqs {
+		if hasSkinTone(item.str) {
+			continue
+		}
+		set := sets[toneKey(item.str)]
+		if set == nil {
+			continue
+		}
+		v, _ := set.variant(tw.skinTone)
+		if i, ok := byStr[v]; ok {
+			toned[item.str] = seqs[i]
+		}
+	}
+	return toned
+}
@@ -0,0 +1,70 @@
+package emojify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSkinTones(t *testing.T) {
+	tw := New()
+	tests := []struct {
+		emoji string
+		n     int
+		tone  string // with MediumSkinTone
+		mixed string // with LightSkinTone, DarkSkinTone
+		base  string
+	}{
+		{emoji: "👍", n: 1, tone: "👍🏽", mixed: "👍", base: "👍"},
+		{emoji: "👍🏻", n: 1, tone: "👍🏽", mixed: "👍🏻", base: "👍"},
+		{emoji: "☝", n: 1, tone: "☝🏽", mixed: "☝", base: "☝️"},
+		{emoji: "🧑‍🤝‍🧑", n: 2, tone: "🧑🏽‍🤝‍🧑🏽", mixed: "🧑🏻‍🤝‍🧑🏿", base: "🧑‍🤝‍🧑"},
+		{emoji: "👫", n: 2, tone: "👫🏽", mixed: "👩🏻‍🤝‍👨🏿", base: "👫"},
+		{emoji: "👩🏻‍🤝‍👨🏿", n: 2, tone: "👫🏽", mixed: "👩🏻‍🤝‍👨🏿", base: "👫"},
+		{emoji: "🤝", n: 2, tone: "🤝🏽", mixed: "🫱🏻‍🫲🏿", base: "🤝"},
+		{emoji: "😀", n: 0, tone: "😀", mixed: "😀", base: "😀"},
+		{emoji: "hello", n: 0, tone: "hello", mixed: "hello", base: "hello"},
+	}
+	for _, test := range tests {
+		if got := tw.SkinTones(test.emoji); got != test.n {
+			t.Errorf("SkinTones(%q) = %d, want %d", test.emoji, got, test.n)
+		}
+		if got := tw.SupportsSkinTone(test.emoji); got != (test.n > 0) {
+			t.Errorf("SupportsSkinTone(%q) = %v", test.emoji, got)
+		}
+		if got := tw.WithSkinTone(test.emoji, MediumSkinTone); got != test.tone {
+			t.Errorf("WithSkinTone(%q, medium) = %q, want %q", test.emoji, got, test.tone)
+		}
+		if got := tw.WithSkinTone(test.emoji, LightSkinTone, DarkSkinTone); got != test.mixed {
+			t.Errorf("WithSkinTone(%q, light, dark) = %q, want %q", test.emoji, got, test.mixed)
+		}
+		if got := tw.StripSkinTone(test.emoji); got != test.base {
+			t.Errorf("StripSkinTone(%q) = %q, want %q", test.emoji, got, test.base)
+		}
+	}
+	if got := tw.WithSkinTone("👍🏿", NoSkinTone); got != "👍" {
+		t.Errorf("WithSkinTone(👍🏿, none) = %q", got)
+	}
+}
+
+func TestDefaultSkinTone(t *testing.T) {
+	tw := New(WithDefaultSkinTone(DarkSkinTone), WithShortcodes(GitHub))
+	got := tw.Replace("👍 :+1: 👍🏻 😀")
+	for _, want := range []string{`1f44d-1f3ff.svg`, `alt="👍"`, `1f44d-1f3fb.svg`, `1f600.svg`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Replace: %s missing %s", got, want)
+		}
+	}
+	if n := strings.Count(got, "1f44d-1f3ff.svg"); n != 2 {
+		t.Errorf("Replace: got %d dark thumbs, want 2: %s", n, got)
+	}
+	if text, err := tw.Unreplace(tw.Replace("👍 👍🏻")); err != nil || text != "👍 👍🏻" {
+		t.Errorf("Unreplace = %q, %v", text, err)
+	}
+
+	_, err := NewTwemoji(WithDefaultSkinTone('x'))
+	var oe *OptionError
+	if !errors.As(err, &oe) || oe.Option != "WithDefaultSkinTone" {
+		t.Errorf("invalid tone: got %v", err)
+	}
+}
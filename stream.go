@@ -0,0 +1,135 @@
+package emojify
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// NewWriter returns a writer that replaces emoji in text written to it with <img> tags, like [Twemoji.Replace],
+// before writing to w. Text that might be the beginning of an emoji sequence is held back until it's resolved,
+// so input can be split at any point. Close must be called to flush any remaining text. It does not close w.
+// Does NOT sanitize its input.
+func (tw Twemoji) NewWriter(w io.Writer) io.WriteCloser {
+	if tw.replacer == nil {
+		return defaults().NewWriter(w)
+	}
+	return &writer{tw: tw, w: w}
+}
+
+type writer struct {
+	tw  Twemoji
+	w   io.Writer
+	buf []byte
+}
+
+// Write accepts all of p into the buffer, so it returns len(p) even if flushing it fails.
+// Whatever wasn't written is kept for the next Write or Close.
+func (w *writer) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), w.flush(false)
+}
+
+func (w *writer) Close() error {
+	return w.flush(true)
+}
+
+func (w *writer) flush(atEOF bool) error {
+	text := string(w.buf)
+	var pos int
+	var err error
+	for pos < len(text) {
+		idx, m, more := w.tw.next(text[pos:], atEOF)
+		if idx > 0 {
+			if _, err = io.WriteString(w.w, text[pos:pos+idx]); err != nil {
+				break
+			}
+			pos += idx
+		}
+		if m != nil {
+			if _, err = io.WriteString(w.w, m.elem); err != nil {
+				break
+			}
+			pos += len(m.str)
+			continue
+		}
+		if more {
+			break
+		}
+	}
+	w.buf = append(w.buf[:0], text[pos:]...)
+	return err
+}
+
+// Transformer returns a [transform.Transformer] that replaces emoji with <img> tags, like [Twemoji.Replace].
+// Does NOT sanitize its input.
+func (tw Twemoji) Transformer() transform.Transformer {
+	if tw.replacer == nil {
+		return defaults().Transformer()
+	}
+	return transformer{tw: tw}
+}
+
+type transformer struct {
+	tw Twemoji
+}
+
+func (t transformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	text := string(src)
+	for nSrc < len(text) {
+		idx, m, more := t.tw.next(text[nSrc:], atEOF)
+		n := copy(dst[nDst:], text[nSrc:nSrc+idx])
+		nDst += n
+		nSrc += n
+		if n < idx {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		if m != nil {
+			if len(dst)-nDst < len(m.elem) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			nDst += copy(dst[nDst:], m.elem)
+			nSrc += len(m.str)
+			continue
+		}
+		if more {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+	}
+	return nDst, nSrc, nil
+}
+
+func (transformer) Reset() {}
+
+// next finds the first emoji in text, returning its index and replacement.
+// If text (unless atEOF) ends with what could be the beginning of an emoji,
+// more is true and idx is where it begins. Otherwise, idx is len(text).
+func (tw Twemoji) next(text string, atEOF bool) (idx int, match *resource, more bool) {
+	for idx < len(text) {
+		char, size := rune(text[idx]), 1
+		if char >= utf8.RuneSelf {
+			if !atEOF && !utf8.FullRuneInString(text[idx:]) {
+				return idx, nil, true
+			}
+			char, size = utf8.DecodeRuneInString(text[idx:])
+		} else if strings.IndexByte(tw.heads, text[idx]) == -1 {
+			idx++
+			continue
+		}
+
+		candidates := tw.nodes[char]
+		for i := range candidates {
+			m := &candidates[i]
+			if strings.HasPrefix(text[idx:], m.str) {
+				return idx, m, false
+			}
+			if !atEOF && len(m.str) > len(text)-idx && strings.HasPrefix(m.str, text[idx:]) {
+				return idx, nil, true
+			}
+		}
+		idx += size
+	}
+	return idx, nil, false
+}
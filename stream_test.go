@@ -0,0 +1,115 @@
+package emojify
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/transform"
+)
+
+func TestNewWriter(t *testing.T) {
+	tw := New(WithShortcodes(GitHub))
+	table := []string{
+		"hello 🐦‍⬛ world 🌎 for 🐦 & 🦤! 5️⃣ 6⃣ #️⃣",
+		"👍🏽👍👍🏿 :+1: :thumbsup :smile:",
+		"👨‍👩‍👧‍👦 family 👨‍👩‍👧 partial 👨‍",
+		"no emoji",
+		"",
+	}
+	for _, in := range table {
+		want := tw.Replace(in)
+		// every way of splitting the input in two
+		for split := 0; split <= len(in); split++ {
+			var buf strings.Builder
+			w := tw.NewWriter(&buf)
+			io.WriteString(w, in[:split])
+			io.WriteString(w, in[split:])
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != want {
+				t.Errorf("split %q|%q →\n got: %q\nwant: %q", in[:split], in[split:], got, want)
+			}
+		}
+		// byte by byte
+		var buf strings.Builder
+		w := tw.NewWriter(&buf)
+		for i := 0; i < len(in); i++ {
+			w.Write([]byte{in[i]})
+		}
+		w.Close()
+		if got := buf.String(); got != want {
+			t.Errorf("bytewise %q →\n got: %q\nwant: %q", in, got, want)
+		}
+	}
+}
+
+// flakyWriter fails its first write.
+type flakyWriter struct {
+	buf    strings.Builder
+	failed bool
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if !w.failed {
+		w.failed = true
+		return 0, io.ErrShortWrite
+	}
+	return w.buf.Write(p)
+}
+
+func TestNewWriterError(t *testing.T) {
+	tw := New()
+	var out flakyWriter
+	w := tw.NewWriter(&out)
+	var failed bool
+	for _, s := range []string{"hi 🌎 ", "bye ", "👋"} {
+		n, err := io.WriteString(w, s)
+		if n != len(s) {
+			t.Errorf("Write(%q) = %d, %v", s, n, err)
+		}
+		failed = failed || err != nil
+	}
+	if !failed {
+		t.Error("expected an error")
+	}
+	// nothing is written twice or lost
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.buf.String(), tw.Replace("hi 🌎 bye 👋"); got != want {
+		t.Errorf("got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestTransformer(t *testing.T) {
+	const in = "hello 🐦‍⬛ world 🌎 for 🐦 & 🦤! 5️⃣"
+	got, _, err := transform.String(Default.Transformer(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Replace(in); got != want {
+		t.Errorf("Transformer →\n got: %q\nwant: %q", got, want)
+	}
+
+	var buf strings.Builder
+	w := transform.NewWriter(&buf, Default.Transformer())
+	for _, r := range in {
+		io.WriteString(w, string(r))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := Replace(in); buf.String() != want {
+		t.Errorf("transform.Writer →\n got: %q\nwant: %q", buf.String(), want)
+	}
+}
+
+func BenchmarkTwemojiWriter(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		w := Default.NewWriter(io.Discard)
+		io.WriteString(w, "hello 🐦‍⬛ world 🌎 for 🐦 & 5️⃣!")
+		w.Close()
+	}
+}
@@ -0,0 +1,121 @@
+package emojify
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// svgNode returns an inline <svg> element for the given emoji image.
+func (tw Twemoji) svgNode(e Emoji, name string) (*html.Node, error) {
+	raw, err := tw.readAsset(name)
+	if err != nil {
+		return nil, err
+	}
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	frags, err := html.ParseFragment(bytes.NewReader(raw), body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid svg %s: %w", name, err)
+	}
+	var svg *html.Node
+	for _, frag := range frags {
+		if frag.Type == html.ElementNode && frag.DataAtom == atom.Svg {
+			svg = frag
+			break
+		}
+	}
+	if svg == nil {
+		return nil, fmt.Errorf("invalid svg %s: no <svg> element", name)
+	}
+	viewBox := "0 0 36 36"
+	for _, attr := range svg.Attr {
+		if attr.Key == "viewBox" {
+			viewBox = attr.Val
+		}
+	}
+	svg.Attr = append(tw.classAttrs(), html.Attribute{Key: "viewBox", Val: viewBox})
+	svg.Attr = append(svg.Attr, tw.size.attrs()...)
+	label := e.Text
+	if tw.ariaLabel {
+		label = tw.label(e)
+	}
+	svg.Attr = append(svg.Attr,
+		html.Attribute{Key: "role", Val: "img"},
+		html.Attribute{Key: "aria-label", Val: label},
+	)
+	if tw.title {
+		title := &html.Node{Type: html.ElementNode, Data: "title", Namespace: svg.Namespace}
+		title.AppendChild(&html.Node{Type: html.TextNode, Data: tw.label(e)})
+		svg.InsertBefore(title, svg.FirstChild)
+	}
+	return svg, nil
+}
+
+// inlineSVG returns a copy of the given <svg> element.
+// The first time an emoji is seen, its image is defined as a <symbol> that later copies <use>.
+// Other elements, such as the <img> of a custom emoji, are copied as-is.
+func inlineSVG(node *html.Node, name string, seen map[string]bool) *html.Node {
+	if node.Data != "svg" {
+		clone := *node
+		return &clone
+	}
+	base := path.Base(name)
+	id := "emoji-" + strings.TrimSuffix(base, path.Ext(base))
+	svg := &html.Node{
+		Type:      html.ElementNode,
+		Data:      node.Data,
+		DataAtom:  node.DataAtom,
+		Namespace: node.Namespace,
+		Attr:      slices.Clone(node.Attr),
+	}
+	// a leading <title> (see WithTitle) labels each use
+	content := node.FirstChild
+	for ; content != nil && content.Type == html.ElementNode && content.Data == "title"; content = content.NextSibling {
+		svg.AppendChild(cloneNode(content))
+	}
+	if !seen[id] {
+		seen[id] = true
+		symbol := &html.Node{
+			Type:      html.ElementNode,
+			Data:      "symbol",
+			Namespace: node.Namespace,
+			Attr:      []html.Attribute{{Key: "id", Val: id}},
+		}
+		for _, attr := range node.Attr {
+			if attr.Key == "viewBox" {
+				symbol.Attr = append(symbol.Attr, attr)
+			}
+		}
+		for child := content; child != nil; child = child.NextSibling {
+			symbol.AppendChild(cloneNode(child))
+		}
+		svg.AppendChild(symbol)
+	}
+	svg.AppendChild(&html.Node{
+		Type:      html.ElementNode,
+		Data:      "use",
+		Namespace: node.Namespace,
+		Attr:      []html.Attribute{{Key: "href", Val: "#" + id}},
+	})
+	return svg
+}
+
+// cloneNode returns a deep copy of node.
+func cloneNode(node *html.Node) *html.Node {
+	clone := &html.Node{
+		Type:      node.Type,
+		DataAtom:  node.DataAtom,
+		Data:      node.Data,
+		Namespace: node.Namespace,
+		Attr:      slices.Clone(node.Attr),
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		clone.AppendChild(cloneNode(child))
+	}
+	return clone
+}
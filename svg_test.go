@@ -0,0 +1,68 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// testAssets returns fake Twemoji assets with an image for every emoji.
+func testAssets() fstest.MapFS {
+	fsys := make(fstest.MapFS, len(twemojiData)*2)
+	for _, item := range twemojiData {
+		name := strings.TrimSuffix(item.img, ".svg")
+		fsys["svg/"+item.img] = &fstest.MapFile{
+			Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 36 36"><circle fill="#FFCC4D" cx="18" cy="18" r="18"/><title>` + name + `</title></svg>`),
+		}
+		fsys["72x72/"+name+".png"] = &fstest.MapFile{
+			Data: []byte("\x89PNG\r\n\x1a\n" + name),
+		}
+	}
+	return fsys
+}
+
+func TestInlineSVG(t *testing.T) {
+	tw := New(WithFormat(InlineSVG), WithAssets(testAssets()))
+
+	const globe = `<svg class="emoji" viewBox="0 0 36 36" width="72" height="72" role="img" aria-label="🌎"><circle fill="#FFCC4D" cx="18" cy="18" r="18"></circle><title>1f30e</title></svg>`
+	if got, want := tw.Replace("hello 🌎"), "hello "+globe; got != want {
+		t.Errorf("Replace →\n got: %q\nwant: %q", got, want)
+	}
+
+	doc := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P}
+	doc.AppendChild(&html.Node{Type: html.TextNode, Data: "🌎 & 🌎"})
+	tw.ReplaceHTML(doc)
+	var buf strings.Builder
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	const attrs = `class="emoji" viewBox="0 0 36 36" width="72" height="72" role="img" aria-label="🌎"`
+	want := `<p><span>` +
+		`<svg ` + attrs + `><symbol id="emoji-1f30e" viewBox="0 0 36 36"><circle fill="#FFCC4D" cx="18" cy="18" r="18"></circle><title>1f30e</title></symbol><use href="#emoji-1f30e"></use></svg>` +
+		` &amp; ` +
+		`<svg ` + attrs + `><use href="#emoji-1f30e"></use></svg>` +
+		`</span></p>`
+	if got := buf.String(); got != want {
+		t.Errorf("ReplaceHTML →\n got: %q\nwant: %q", got, want)
+	}
+
+	tw.UnreplaceHTML(doc)
+	if doc.FirstChild == nil || doc.FirstChild.Type != html.TextNode || doc.FirstChild.Data != "🌎 & 🌎" {
+		t.Errorf("UnreplaceHTML → %#v", doc.FirstChild)
+	}
+}
+
+func TestInlineSVGNoAssets(t *testing.T) {
+	if embeddedAssets != nil {
+		t.Skip("built with embedded assets")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	New(WithFormat(InlineSVG))
+}
@@ -0,0 +1,129 @@
+package emojify
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Unreplace returns a copy of the HTML fragment s with emoji images turned back into text.
+// It is the inverse of [Twemoji.Replace] and [Twemoji.HTML], except that the output is re-rendered HTML,
+// so characters such as & will be escaped. Use [html.UnescapeString] to get back the original text.
+func (tw Twemoji) Unreplace(s string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	frags, err := html.ParseFragment(strings.NewReader(s), body)
+	if err != nil {
+		return "", err
+	}
+	for _, frag := range frags {
+		body.AppendChild(frag)
+	}
+	tw.UnreplaceHTML(body)
+
+	var buf strings.Builder
+	for node := body.FirstChild; node != nil; node = node.NextSibling {
+		if err := html.Render(&buf, node); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+// UnreplaceHTML mutates the HTML of root, turning emoji images back into text.
+// Images are recognized by their class, their src being under the configured CDN, or their alt text
+// (or aria-label, for inline SVG) being a known emoji.
+// Wrapper elements added by [Twemoji.ReplaceHTML] are removed.
+func (tw Twemoji) UnreplaceHTML(root *html.Node) {
+	if tw.replacer == nil {
+		defaults().UnreplaceHTML(root)
+		return
+	}
+	tw.unreplace(root)
+}
+
+// unreplace turns the emoji images under root back into text,
+// reporting whether any were direct children of root.
+func (tw Twemoji) unreplace(root *html.Node) bool {
+	var hit, changed bool
+	var next *html.Node
+	for node := root.FirstChild; node != nil; node = next {
+		next = node.NextSibling
+		if node.Type != html.ElementNode {
+			continue
+		}
+		if node.DataAtom == atom.Img || node.DataAtom == atom.Svg || node.DataAtom == atom.Picture {
+			if text, ok := tw.unimg(node); ok {
+				replaceChild(root, node, &html.Node{
+					Type: html.TextNode,
+					Data: text,
+				})
+				hit, changed = true, true
+			}
+			continue
+		}
+		if !tw.unreplace(node) {
+			continue
+		}
+		// collapse our wrapper if all that's left is text.
+		// it held the images directly, unlike an author's element around an emoji that was wrapped itself.
+		if tw.wrapTag != "" && node.Data == tw.wrapTag && slices.Equal(node.Attr, tw.wrapAttrs) &&
+			node.FirstChild != nil && node.FirstChild == node.LastChild && node.FirstChild.Type == html.TextNode {
+			replaceChild(root, node, &html.Node{
+				Type: html.TextNode,
+				Data: node.FirstChild.Data,
+			})
+			changed = true
+		}
+	}
+	if changed {
+		mergeText(root)
+	}
+	return hit
+}
+
+// unimg returns the emoji text represented by img (or inline svg), if it's an emoji.
+func (tw Twemoji) unimg(img *html.Node) (string, bool) {
+	if img.DataAtom == atom.Picture {
+		for img = img.LastChild; img != nil && img.DataAtom != atom.Img; img = img.PrevSibling {
+		}
+		if img == nil {
+			return "", false
+		}
+	}
+	var alt, label, src, class string
+	for _, attr := range img.Attr {
+		switch attr.Key {
+		case "alt":
+			alt = attr.Val
+		case "aria-label":
+			label = attr.Val
+		case "src":
+			src = attr.Val
+		case "class":
+			class = attr.Val
+		}
+	}
+	ours := (tw.class != "" && slices.Contains(strings.Fields(class), tw.class)) ||
+		(tw.cdn != "" && strings.HasPrefix(src, tw.cdn))
+	// only our images are labeled with names, see WithAltName
+	text, known := tw.unlabel(ours && (tw.altName || tw.ariaLabel), alt, label)
+	if text == "" {
+		return "", false
+	}
+	return text, ours || known
+}
+
+// mergeText joins adjacent text node children of parent.
+func mergeText(parent *html.Node) {
+	for node := parent.FirstChild; node != nil; node = node.NextSibling {
+		if node.Type != html.TextNode {
+			continue
+		}
+		for next := node.NextSibling; next != nil && next.Type == html.TextNode; next = node.NextSibling {
+			node.Data += next.Data
+			parent.RemoveChild(next)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package emojify
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestUnreplace(t *testing.T) {
+	table := []string{
+		"crow: 🐦‍⬛",
+		"🌎, hello! for 🐦🦤",
+		"6️⃣9️⃣ nice",
+		"no emoji here",
+	}
+	for _, in := range table {
+		t.Run(in, func(t *testing.T) {
+			got, err := Unreplace(Replace(in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != in {
+				t.Errorf("Unreplace(Replace(%q)) →\n got: %q\nwant: %q", in, got, in)
+			}
+		})
+	}
+}
+
+func TestUnreplaceHTML(t *testing.T) {
+	const in = "hello 🐦‍⬛ world 🌎 for 🐦 & 🦤! 5️⃣"
+	text := &html.Node{
+		Type: html.TextNode,
+		Data: in,
+	}
+	doc := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "p",
+		DataAtom: atom.P,
+	}
+	doc.AppendChild(text)
+	ReplaceHTML(doc)
+	UnreplaceHTML(doc)
+
+	if doc.FirstChild == nil || doc.FirstChild != doc.LastChild {
+		t.Fatal("expected a single child")
+	}
+	if got := doc.FirstChild; got.Type != html.TextNode || got.Data != in {
+		t.Errorf("unexpected child: %#v", got)
+	}
+	if doc.FirstChild.Parent != doc {
+		t.Error("bad parent link")
+	}
+
+	// the author's own spans are kept
+	doc, err := html.Parse(strings.NewReader("<p><span>👍</span> 🌎</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ReplaceHTML(doc)
+	UnreplaceHTML(doc)
+	var buf strings.Builder
+	if err := html.Render(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, "<p><span>👍</span> 🌎</p>") {
+		t.Errorf("unexpected output: %s", got)
+	}
+}
+
+func TestUnreplaceForeign(t *testing.T) {
+	table := []struct {
+		in   string
+		want string
+	}{
+		{
+			// recognized by alt
+			in:   `<p>hi <img src="/x.png" alt="👋"></p>`,
+			want: `<p>hi 👋</p>`,
+		},
+		{
+			// recognized by class
+			in:   `<p><img class="big emoji" src="/partyparrot.gif" alt=":partyparrot:"></p>`,
+			want: `<p>:partyparrot:</p>`,
+		},
+		{
+			// not ours
+			in:   `<p><img src="/cat.png" alt="a cat"></p>`,
+			want: `<p><img src="/cat.png" alt="a cat"/></p>`,
+		},
+		{
+			// not ours, named like an emoji
+			in:   `<p><img src="/photos/cat.png" alt="cat"></p>`,
+			want: `<p><img src="/photos/cat.png" alt="cat"/></p>`,
+		},
+		{
+			// unrelated spans are kept
+			in:   `<span class="x">` + Replace("👍") + `</span>`,
+			want: `<span class="x">👍</span>`,
+		},
+	}
+	for _, try := range table {
+		got, err := Unreplace(try.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != try.want {
+			t.Errorf("Unreplace(%q) →\n got: %q\nwant: %q", try.in, got, try.want)
+		}
+	}
+}